@@ -78,6 +78,10 @@ func AllianceStationDisplayWebsocketHandler(w http.ResponseWriter, r *http.Reque
 	defer close(robotStatusListener)
 	matchTimeListener := mainArena.matchTimeNotifier.Listen()
 	defer close(matchTimeListener)
+	matchTimingListener := mainArena.matchTimingNotifier.Listen()
+	defer close(matchTimingListener)
+	timeoutListener := mainArena.timeoutNotifier.Listen()
+	defer close(timeoutListener)
 	realtimeScoreListener := mainArena.realtimeScoreNotifier.Listen()
 	defer close(realtimeScoreListener)
 	reloadDisplaysListener := mainArena.reloadDisplaysNotifier.Listen()
@@ -95,6 +99,11 @@ func AllianceStationDisplayWebsocketHandler(w http.ResponseWriter, r *http.Reque
 		log.Printf("Websocket error: %s", err)
 		return
 	}
+	err = websocket.Write("timeout", mainArena.TimeoutStatus())
+	if err != nil {
+		log.Printf("Websocket error: %s", err)
+		return
+	}
 	err = websocket.Write("matchTime", MatchTimeMessage{mainArena.MatchState, int(mainArena.lastMatchTimeSec)})
 	if err != nil {
 		log.Printf("Websocket error: %s", err)
@@ -114,10 +123,10 @@ func AllianceStationDisplayWebsocketHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 	data = struct {
-		RedScoreFields  *RealtimeScoreFields
-		BlueScoreFields *RealtimeScoreFields
-	}{mainArena.redRealtimeScore.ScoreFields(mainArena.blueRealtimeScore.CurrentScore.Fouls),
-		mainArena.blueRealtimeScore.ScoreFields(mainArena.redRealtimeScore.CurrentScore.Fouls)}
+		RedScoreFields  *ScoreSummary
+		BlueScoreFields *ScoreSummary
+	}{mainArena.redRealtimeScore.ScoreSummary(mainArena.blueRealtimeScore.CurrentScore.Fouls),
+		mainArena.blueRealtimeScore.ScoreSummary(mainArena.redRealtimeScore.CurrentScore.Fouls)}
 	err = websocket.Write("realtimeScore", data)
 	if err != nil {
 		log.Printf("Websocket error: %s", err)
@@ -169,16 +178,28 @@ func AllianceStationDisplayWebsocketHandler(w http.ResponseWriter, r *http.Reque
 				}
 				messageType = "matchTime"
 				message = MatchTimeMessage{mainArena.MatchState, matchTimeSec.(int)}
+			case _, ok := <-matchTimingListener:
+				if !ok {
+					return
+				}
+				messageType = "matchTiming"
+				message = mainArena.matchTiming
+			case _, ok := <-timeoutListener:
+				if !ok {
+					return
+				}
+				messageType = "timeout"
+				message = mainArena.TimeoutStatus()
 			case _, ok := <-realtimeScoreListener:
 				if !ok {
 					return
 				}
 				messageType = "realtimeScore"
 				message = struct {
-					RedScoreFields  *RealtimeScoreFields
-					BlueScoreFields *RealtimeScoreFields
-				}{mainArena.redRealtimeScore.ScoreFields(mainArena.blueRealtimeScore.CurrentScore.Fouls),
-					mainArena.blueRealtimeScore.ScoreFields(mainArena.redRealtimeScore.CurrentScore.Fouls)}
+					RedScoreFields  *ScoreSummary
+					BlueScoreFields *ScoreSummary
+				}{mainArena.redRealtimeScore.ScoreSummary(mainArena.blueRealtimeScore.CurrentScore.Fouls),
+					mainArena.blueRealtimeScore.ScoreSummary(mainArena.redRealtimeScore.CurrentScore.Fouls)}
 			case _, ok := <-reloadDisplaysListener:
 				if !ok {
 					return
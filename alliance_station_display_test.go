@@ -46,6 +46,7 @@ func TestAllianceStationDisplayWebsocket(t *testing.T) {
 	// Should get a few status updates right after connection.
 	readWebsocketType(t, ws, "setAllianceStationDisplay")
 	readWebsocketType(t, ws, "matchTiming")
+	readWebsocketType(t, ws, "timeout")
 	readWebsocketType(t, ws, "matchTime")
 	readWebsocketType(t, ws, "setMatch")
 	readWebsocketType(t, ws, "realtimeScore")
@@ -0,0 +1,36 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore CRUD methods for the record of an alliance having used its timeout in the eliminations.
+
+package main
+
+import "time"
+
+type AllianceTimeout struct {
+	Id         int
+	AllianceId int
+	MatchId    int
+	Time       time.Time
+}
+
+func (database *Database) CreateAllianceTimeout(allianceTimeout *AllianceTimeout) error {
+	return database.allianceTimeoutMap.Insert(allianceTimeout)
+}
+
+func (database *Database) GetAllianceTimeoutByAllianceId(allianceId int) (*AllianceTimeout, error) {
+	var allianceTimeouts []AllianceTimeout
+	err := database.allianceTimeoutMap.Select(&allianceTimeouts,
+		"SELECT * FROM alliance_timeouts WHERE allianceid = ?", allianceId)
+	if err != nil {
+		return nil, err
+	}
+	if len(allianceTimeouts) == 0 {
+		return nil, nil
+	}
+	return &allianceTimeouts[0], err
+}
+
+func (database *Database) TruncateAllianceTimeouts() error {
+	return database.allianceTimeoutMap.TruncateTables()
+}
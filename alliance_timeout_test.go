@@ -0,0 +1,34 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAllianceTimeoutCrud(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	db, err := OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+
+	allianceTimeout, err := db.GetAllianceTimeoutByAllianceId(2)
+	assert.Nil(t, err)
+	assert.Nil(t, allianceTimeout)
+
+	allianceTimeout2 := AllianceTimeout{0, 2, 13, time.Unix(1000, 0).UTC()}
+	assert.Nil(t, db.CreateAllianceTimeout(&allianceTimeout2))
+	allianceTimeout, err = db.GetAllianceTimeoutByAllianceId(2)
+	assert.Nil(t, err)
+	assert.Equal(t, allianceTimeout2, *allianceTimeout)
+	assert.NotNil(t, db.CreateAllianceTimeout(&AllianceTimeout{0, 2, 14, time.Unix(1001, 0).UTC()}))
+
+	db.TruncateAllianceTimeouts()
+	allianceTimeout, err = db.GetAllianceTimeoutByAllianceId(2)
+	assert.Nil(t, err)
+	assert.Nil(t, allianceTimeout)
+}
@@ -52,6 +52,8 @@ func AnnouncerDisplayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 	defer close(matchLoadTeamsListener)
 	matchTimeListener := mainArena.matchTimeNotifier.Listen()
 	defer close(matchTimeListener)
+	matchTimingListener := mainArena.matchTimingNotifier.Listen()
+	defer close(matchTimingListener)
 	realtimeScoreListener := mainArena.realtimeScoreNotifier.Listen()
 	defer close(realtimeScoreListener)
 	scorePostedListener := mainArena.scorePostedNotifier.Listen()
@@ -132,6 +134,12 @@ func AnnouncerDisplayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 				}
 				messageType = "matchTime"
 				message = MatchTimeMessage{mainArena.MatchState, matchTimeSec.(int)}
+			case _, ok := <-matchTimingListener:
+				if !ok {
+					return
+				}
+				messageType = "matchTiming"
+				message = mainArena.matchTiming
 			case _, ok := <-realtimeScoreListener:
 				if !ok {
 					return
@@ -7,8 +7,10 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/gorilla/mux"
 	"net/http"
+	"strconv"
 )
 
 type MatchWithResult struct {
@@ -21,6 +23,12 @@ type RankingWithNickname struct {
 	Nickname string
 }
 
+type StationStopStatus struct {
+	AllianceStation string
+	EmergencyStop   bool
+	AStop           bool
+}
+
 // Generates a JSON dump of the matches and results.
 func MatchesApiHandler(w http.ResponseWriter, r *http.Request) {
 	if !UserIsReader(w, r) {
@@ -59,6 +67,42 @@ func MatchesApiHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Generates a JSON dump of every play and edit of a single match's results, with the changes between versions.
+func MatchResultsApiHandler(w http.ResponseWriter, r *http.Request) {
+	if !UserIsReader(w, r) {
+		return
+	}
+
+	matchId, _ := strconv.Atoi(mux.Vars(r)["id"])
+	match, err := db.GetMatchById(matchId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if match == nil {
+		handleWebErr(w, fmt.Errorf("Error: No such match: %d", matchId))
+		return
+	}
+	entries, err := db.GetMatchResultHistoryEntries(matchId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	jsonData, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(jsonData)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
 // Generates a JSON dump of the sponsor slides for use by the audience display.
 func SponsorSlidesApiHandler(w http.ResponseWriter, r *http.Request) {
 	if !UserIsReader(w, r) {
@@ -148,3 +192,68 @@ func RankingsApiHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// Generates a JSON dump of the current state of the playoff bracket.
+func BracketApiHandler(w http.ResponseWriter, r *http.Request) {
+	if !UserIsReader(w, r) {
+		return
+	}
+
+	bracket, err := db.GetPlayoffBracket()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	jsonData, err := json.MarshalIndent(bracket, "", "  ")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(jsonData)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Latches the emergency stop for a single alliance station, for use by external field hardware.
+func EmergencyStopApiHandler(w http.ResponseWriter, r *http.Request) {
+	handleStationStopApiRequest(w, r, mainArena.EmergencyStopStation)
+}
+
+// Latches the autonomous stop for a single alliance station, for use by external field hardware.
+func AStopApiHandler(w http.ResponseWriter, r *http.Request) {
+	handleStationStopApiRequest(w, r, mainArena.AStopStation)
+}
+
+// Applies the given stop to the station in the request and responds with the station's resulting stop status.
+func handleStationStopApiRequest(w http.ResponseWriter, r *http.Request, stop func(string, string) error) {
+	if !UserIsAdmin(w, r) {
+		return
+	}
+
+	station := mux.Vars(r)["station"]
+	err := stop(station, getRequestUser(r))
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	allianceStation := mainArena.AllianceStations[station]
+	jsonData, err := json.MarshalIndent(StationStopStatus{station, allianceStation.EmergencyStop,
+		allianceStation.AStop}, "", "  ")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(jsonData)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
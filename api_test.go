@@ -59,8 +59,8 @@ func TestRankingsApi(t *testing.T) {
 	assert.Equal(t, 0, len(rankingsData.Rankings))
 	assert.Equal(t, "", rankingsData.HighestPlayedMatch)
 
-	ranking1 := RankingWithNickname{Ranking{1114, 2, 18, 625, 90, 554, 9, 0.254, 3, 2, 1, 0, 10}, "Simbots"}
-	ranking2 := RankingWithNickname{Ranking{254, 1, 20, 625, 90, 554, 10, 0.254, 1, 2, 3, 0, 10}, "ChezyPof"}
+	ranking1 := RankingWithNickname{Ranking{1114, 2, 18, []int{625, 90, 554, 9}, 0.254, 3, 2, 1, 0, 10}, "Simbots"}
+	ranking2 := RankingWithNickname{Ranking{254, 1, 20, []int{625, 90, 554, 10}, 0.254, 1, 2, 3, 0, 10}, "ChezyPof"}
 	db.CreateRanking(&ranking1.Ranking)
 	db.CreateRanking(&ranking2.Ranking)
 	db.CreateMatch(&Match{Type: "qualification", DisplayName: "29", Status: "complete"})
@@ -101,3 +101,58 @@ func TestSponsorSlides(t *testing.T) {
 		assert.Equal(t, slide2, sponsorSlides[1])
 	}
 }
+
+func TestBracketApi(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	db, _ = OpenDatabase(testDbPath)
+	eventSettings, _ = db.GetEventSettings()
+
+	createTestAlliances(db, 2)
+	db.UpdateEliminationSchedule(time.Unix(0, 0))
+	scoreMatch(db, "F-1", "R")
+
+	recorder := getHttpResponse("/api/bracket")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/json", recorder.HeaderMap["Content-Type"][0])
+	var bracket PlayoffBracket
+	err := json.Unmarshal([]byte(recorder.Body.String()), &bracket)
+	assert.Nil(t, err)
+	assert.Equal(t, "Single Elimination", bracket.Format)
+	if assert.Equal(t, 1, len(bracket.Series)) {
+		assert.Equal(t, "F", bracket.Series[0].Name)
+		assert.Equal(t, 1, bracket.Series[0].RedWins)
+		assert.Equal(t, "F-2", bracket.Series[0].NextMatchName)
+	}
+}
+
+func TestStationStopApi(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	db, _ = OpenDatabase(testDbPath)
+	eventSettings, _ = db.GetEventSettings()
+	mainArena.Setup()
+
+	recorder := postHttpResponse("/api/stations/R4/emergency_stop", "")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid alliance station")
+
+	recorder = postHttpResponse("/api/stations/B2/emergency_stop", "")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/json", recorder.HeaderMap["Content-Type"][0])
+	var status StationStopStatus
+	err := json.Unmarshal([]byte(recorder.Body.String()), &status)
+	assert.Nil(t, err)
+	assert.Equal(t, StationStopStatus{"B2", true, false}, status)
+	assert.Equal(t, true, mainArena.AllianceStations["B2"].EmergencyStop)
+
+	recorder = postHttpResponse("/api/stations/R1/a_stop", "")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, true, mainArena.AllianceStations["R1"].AStop)
+
+	// Check that the API requires authentication once a password is set.
+	eventSettings.AdminPassword = "blorpy"
+	recorder = postHttpResponse("/api/stations/R2/emergency_stop", "")
+	assert.Equal(t, 401, recorder.Code)
+	assert.Equal(t, false, mainArena.AllianceStations["R2"].EmergencyStop)
+}
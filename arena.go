@@ -7,7 +7,6 @@ package main
 
 import (
 	"fmt"
-	"log"
 	"time"
 )
 
@@ -31,8 +30,16 @@ const (
 type AllianceStation struct {
 	DsConn        *DriverStationConnection
 	EmergencyStop bool
+	AStop         bool
 	Bypass        bool
 	Team          *Team
+
+	// Station state as of the last check, for detecting changes to record as connection and match events.
+	wasDsLinked      bool
+	wasRobotLinked   bool
+	wasEmergencyStop bool
+	wasAStop         bool
+	wasBypassed      bool
 }
 
 // Match period timings.
@@ -56,12 +63,15 @@ type Arena struct {
 	CanStartMatch                  bool
 	matchTiming                    MatchTiming
 	currentMatch                   *Match
+	dsMatchNumber                  int
+	dsRepeatNumber                 int
 	redRealtimeScore               *RealtimeScore
 	blueRealtimeScore              *RealtimeScore
 	matchStartTime                 time.Time
 	lastDsPacketTime               time.Time
 	matchStateNotifier             *Notifier
 	matchTimeNotifier              *Notifier
+	matchTimingNotifier            *Notifier
 	robotStatusNotifier            *Notifier
 	matchLoadTeamsNotifier         *Notifier
 	scoringStatusNotifier          *Notifier
@@ -71,9 +81,12 @@ type Arena struct {
 	playSoundNotifier              *Notifier
 	allianceStationDisplayNotifier *Notifier
 	allianceSelectionNotifier      *Notifier
+	bracketNotifier                *Notifier
 	lowerThirdNotifier             *Notifier
 	reloadDisplaysNotifier         *Notifier
 	defenseSelectionNotifier       *Notifier
+	timeoutNotifier                *Notifier
+	fieldResetTimerNotifier        *Notifier
 	audienceDisplayScreen          string
 	allianceStationDisplays        map[string]string
 	allianceStationDisplayScreen   string
@@ -84,12 +97,11 @@ type Arena struct {
 	lights                         Lights
 	muteMatchSounds                bool
 	fieldReset                     bool
-}
-
-type RealtimeScoreFields struct {
-	Score            int
-	TowerStrength    int
-	DefensesStrength [5]int
+	timeoutType                    string
+	timeoutEndTime                 time.Time
+	lastTimeoutRemainingSec        int
+	fieldResetStartTime            time.Time
+	lastFieldResetTimerSec         int
 }
 
 var mainArena Arena // Named thusly to avoid polluting the global namespace with something more generic.
@@ -102,11 +114,6 @@ func NewRealtimeScore() *RealtimeScore {
 
 // Sets the arena to its initial state.
 func (arena *Arena) Setup() {
-	arena.matchTiming.AutoDurationSec = 15
-	arena.matchTiming.PauseDurationSec = 2
-	arena.matchTiming.TeleopDurationSec = 135
-	arena.matchTiming.EndgameTimeLeftSec = 30
-
 	arena.AllianceStations = make(map[string]*AllianceStation)
 	arena.AllianceStations["R1"] = new(AllianceStation)
 	arena.AllianceStations["R2"] = new(AllianceStation)
@@ -117,6 +124,7 @@ func (arena *Arena) Setup() {
 
 	arena.matchStateNotifier = NewNotifier()
 	arena.matchTimeNotifier = NewNotifier()
+	arena.matchTimingNotifier = NewNotifier()
 	arena.robotStatusNotifier = NewNotifier()
 	arena.matchLoadTeamsNotifier = NewNotifier()
 	arena.scoringStatusNotifier = NewNotifier()
@@ -126,11 +134,16 @@ func (arena *Arena) Setup() {
 	arena.playSoundNotifier = NewNotifier()
 	arena.allianceStationDisplayNotifier = NewNotifier()
 	arena.allianceSelectionNotifier = NewNotifier()
+	arena.bracketNotifier = NewNotifier()
 	arena.lowerThirdNotifier = NewNotifier()
 	arena.reloadDisplaysNotifier = NewNotifier()
 	arena.defenseSelectionNotifier = NewNotifier()
+	arena.timeoutNotifier = NewNotifier()
+	arena.fieldResetTimerNotifier = NewNotifier()
 
-	arena.lights.Setup()
+	if err := arena.lights.Setup(); err != nil {
+		logBuffer.Error(lightsSubsystem, "Failed to set up lights: %v", err)
+	}
 
 	// Load empty match as current.
 	arena.MatchState = PRE_MATCH
@@ -144,6 +157,9 @@ func (arena *Arena) Setup() {
 	arena.savedMatchResult = &MatchResult{}
 	arena.allianceStationDisplays = make(map[string]string)
 	arena.allianceStationDisplayScreen = "match"
+
+	arena.timeoutType = ""
+	arena.fieldResetStartTime = time.Time{}
 }
 
 // Loads a team into an alliance station, cleaning up the previous team there if there is one.
@@ -164,6 +180,13 @@ func (arena *Arena) AssignTeam(teamId int, station string) error {
 		arena.AllianceStations[station].DsConn = nil
 	}
 
+	// Start tracking the connection state afresh for the new team without recording the old team's departure.
+	arena.AllianceStations[station].wasDsLinked = false
+	arena.AllianceStations[station].wasRobotLinked = false
+	arena.AllianceStations[station].wasEmergencyStop = false
+	arena.AllianceStations[station].wasAStop = false
+	arena.AllianceStations[station].wasBypassed = false
+
 	// Leave the station empty if the team number is zero.
 	if teamId == 0 {
 		arena.AllianceStations[station].Team = nil
@@ -189,8 +212,15 @@ func (arena *Arena) LoadMatch(match *Match) error {
 		return fmt.Errorf("Cannot load match while there is a match still in progress or with results pending.")
 	}
 
+	dsMatchNumber, dsRepeatNumber, err := getDsMatchNumbers(match)
+	if err != nil {
+		return err
+	}
 	arena.currentMatch = match
-	err := arena.AssignTeam(match.Red1, "R1")
+	arena.dsMatchNumber = dsMatchNumber
+	arena.dsRepeatNumber = dsRepeatNumber
+	arena.UpdateMatchTiming()
+	err = arena.AssignTeam(match.Red1, "R1")
 	if err != nil {
 		return err
 	}
@@ -233,6 +263,17 @@ func (arena *Arena) LoadMatch(match *Match) error {
 	return nil
 }
 
+// Applies the match period timings from the event settings, notifying any listeners if they have changed. Should
+// only be called while no match is in progress.
+func (arena *Arena) UpdateMatchTiming() {
+	matchTiming := MatchTiming{eventSettings.AutoDurationSec, eventSettings.PauseDurationSec,
+		eventSettings.TeleopDurationSec, eventSettings.EndgameTimeLeftSec}
+	if matchTiming != arena.matchTiming {
+		arena.matchTiming = matchTiming
+		arena.matchTimingNotifier.Notify(nil)
+	}
+}
+
 // Sets a new test match containing no teams as the current match.
 func (arena *Arena) LoadTestMatch() error {
 	return arena.LoadMatch(&Match{Type: "test"})
@@ -296,7 +337,7 @@ func (arena *Arena) SetupNetwork() {
 				arena.AllianceStations["R3"].Team, arena.AllianceStations["B1"].Team,
 				arena.AllianceStations["B2"].Team, arena.AllianceStations["B3"].Team)
 			if err != nil {
-				log.Printf("Failed to configure team WiFi: %s", err.Error())
+				logBuffer.Error(networkSubsystem, "Failed to configure team WiFi: %s", err.Error())
 			}
 		}()
 		go func() {
@@ -304,7 +345,7 @@ func (arena *Arena) SetupNetwork() {
 				arena.AllianceStations["R3"].Team, arena.AllianceStations["B1"].Team,
 				arena.AllianceStations["B2"].Team, arena.AllianceStations["B3"].Team)
 			if err != nil {
-				log.Printf("Failed to configure team Ethernet: %s", err.Error())
+				logBuffer.Error(networkSubsystem, "Failed to configure team Ethernet: %s", err.Error())
 			}
 		}()
 	}
@@ -315,6 +356,9 @@ func (arena *Arena) CheckCanStartMatch() error {
 	if arena.MatchState != PRE_MATCH {
 		return fmt.Errorf("Cannot start match while there is a match still in progress or with results pending.")
 	}
+	if arena.timeoutType != "" && arena.currentMatch.Type == "elimination" {
+		return fmt.Errorf("Cannot start match while a timeout is in progress.")
+	}
 	for _, allianceStation := range arena.AllianceStations {
 		if allianceStation.EmergencyStop {
 			return fmt.Errorf("Cannot start match while an emergency stop is active.")
@@ -343,7 +387,8 @@ func (arena *Arena) StartMatch() error {
 			if allianceStation.DsConn != nil {
 				err = allianceStation.DsConn.signalMatchStart(arena.currentMatch)
 				if err != nil {
-					log.Println(err)
+					logBuffer.Error(dsSubsystem, "Failed to start match log for team %d: %v",
+						allianceStation.DsConn.TeamId, err)
 				}
 			}
 		}
@@ -373,16 +418,63 @@ func (arena *Arena) ResetMatch() error {
 		return fmt.Errorf("Cannot reset match while it is in progress.")
 	}
 	arena.MatchState = PRE_MATCH
-	arena.AllianceStations["R1"].Bypass = false
-	arena.AllianceStations["R2"].Bypass = false
-	arena.AllianceStations["R3"].Bypass = false
-	arena.AllianceStations["B1"].Bypass = false
-	arena.AllianceStations["B2"].Bypass = false
-	arena.AllianceStations["B3"].Bypass = false
+	for station, allianceStation := range arena.AllianceStations {
+		if allianceStation.EmergencyStop || allianceStation.AStop {
+			logBuffer.Info(arenaSubsystem, "Clearing stops for station %s on match reset.", station)
+		}
+		allianceStation.Bypass = false
+		allianceStation.EmergencyStop = false
+		allianceStation.AStop = false
+	}
 	arena.muteMatchSounds = false
 	return nil
 }
 
+// Latches the emergency stop for the given station, disabling its robot for the remainder of the match. The stop can
+// only be cleared by resetting the match.
+func (arena *Arena) EmergencyStopStation(station string, source string) error {
+	allianceStation, ok := arena.AllianceStations[station]
+	if !ok {
+		return fmt.Errorf("Invalid alliance station '%s'.", station)
+	}
+	if !allianceStation.EmergencyStop {
+		logBuffer.Warning(arenaSubsystem, "Emergency stop activated for station %s%s by %s.", station,
+			describeStationTeam(allianceStation), source)
+	}
+	allianceStation.EmergencyStop = true
+
+	// Send the stop out to the driver station right away instead of waiting for the next periodic packet.
+	arena.lastDsPacketTime = time.Time{}
+	return nil
+}
+
+// Latches the autonomous stop for the given station, disabling its robot for the remainder of the autonomous period
+// only. The stop can only be cleared by resetting the match.
+func (arena *Arena) AStopStation(station string, source string) error {
+	allianceStation, ok := arena.AllianceStations[station]
+	if !ok {
+		return fmt.Errorf("Invalid alliance station '%s'.", station)
+	}
+	if arena.MatchState > AUTO_PERIOD {
+		return fmt.Errorf("Cannot autonomous stop a robot after the autonomous period has ended.")
+	}
+	if !allianceStation.AStop {
+		logBuffer.Warning(arenaSubsystem, "Autonomous stop activated for station %s%s by %s.", station,
+			describeStationTeam(allianceStation), source)
+	}
+	allianceStation.AStop = true
+	arena.lastDsPacketTime = time.Time{}
+	return nil
+}
+
+// Returns a description of the team in the given station for inclusion in log messages.
+func describeStationTeam(allianceStation *AllianceStation) string {
+	if allianceStation.Team == nil {
+		return ""
+	}
+	return fmt.Sprintf(" (Team %d)", allianceStation.Team.Id)
+}
+
 // Returns the fractional number of seconds since the start of the match.
 func (arena *Arena) MatchTimeSec() float64 {
 	if arena.MatchState == PRE_MATCH || arena.MatchState == START_MATCH || arena.MatchState == POST_MATCH {
@@ -479,6 +571,13 @@ func (arena *Arena) Update() {
 	// Send a notification if the match state has changed.
 	if arena.MatchState != arena.lastMatchState {
 		arena.matchStateNotifier.Notify(arena.MatchState)
+
+		// Time the field reset from the end of the match until the next one starts.
+		if arena.MatchState == POST_MATCH {
+			arena.startFieldResetTimer()
+		} else if arena.MatchState == AUTO_PERIOD {
+			arena.stopFieldResetTimer()
+		}
 	}
 	arena.lastMatchState = arena.MatchState
 
@@ -488,11 +587,15 @@ func (arena *Arena) Update() {
 	}
 	arena.lastMatchTimeSec = matchTimeSec
 
+	arena.updateTimeout()
+	arena.updateFieldResetTimer()
+
 	// Send a packet if at a period transition point or if it's been long enough since the last one.
 	if sendDsPacket || time.Since(arena.lastDsPacketTime).Seconds()*1000 >= dsPacketPeriodMs {
 		arena.sendDsPacket(auto, enabled)
 		arena.robotStatusNotifier.Notify(nil)
 	}
+	arena.recordStationEvents()
 
 	arena.handleLighting()
 }
@@ -510,30 +613,104 @@ func (arena *Arena) sendDsPacket(auto bool, enabled bool) {
 		dsConn := allianceStation.DsConn
 		if dsConn != nil {
 			dsConn.Auto = auto
-			dsConn.Enabled = enabled && !allianceStation.EmergencyStop && !allianceStation.Bypass
+			dsConn.EmergencyStop = allianceStation.EmergencyStop
+			dsConn.Enabled = enabled && !allianceStation.EmergencyStop && !allianceStation.Bypass &&
+				!(auto && allianceStation.AStop)
 			err := dsConn.Update()
 			if err != nil {
-				log.Printf("Unable to send driver station packet for team %d.", allianceStation.Team.Id)
+				logBuffer.Warning(dsSubsystem, "Unable to send driver station packet for team %d.",
+					allianceStation.Team.Id)
 			}
 		}
 	}
 	arena.lastDsPacketTime = time.Now()
 }
 
+// Persists any changes in the state of the alliance stations since the last check, both to each team's
+// connection history and to the event log of the loaded match, so that problems can be reviewed after the fact.
+func (arena *Arena) recordStationEvents() {
+	for station, allianceStation := range arena.AllianceStations {
+		if allianceStation.Team == nil {
+			continue
+		}
+		dsLinked := allianceStation.DsConn != nil && allianceStation.DsConn.DsLinked
+		robotLinked := allianceStation.DsConn != nil && allianceStation.DsConn.RobotLinked
+
+		var eventTypes []string
+		if dsLinked != allianceStation.wasDsLinked {
+			if dsLinked {
+				eventTypes = append(eventTypes, dsLinkedEvent)
+			} else {
+				eventTypes = append(eventTypes, dsDisconnectedEvent)
+			}
+		}
+		if robotLinked != allianceStation.wasRobotLinked {
+			if robotLinked {
+				eventTypes = append(eventTypes, robotLinkedEvent)
+			} else {
+				eventTypes = append(eventTypes, robotDisconnectedEvent)
+			}
+		}
+		if allianceStation.EmergencyStop && !allianceStation.wasEmergencyStop {
+			eventTypes = append(eventTypes, emergencyStopEvent)
+		}
+		connectionEventTypes := eventTypes
+		if allianceStation.AStop && !allianceStation.wasAStop {
+			eventTypes = append(eventTypes, aStopEvent)
+		}
+		if allianceStation.Bypass != allianceStation.wasBypassed {
+			if allianceStation.Bypass {
+				eventTypes = append(eventTypes, bypassedEvent)
+			} else {
+				eventTypes = append(eventTypes, bypassClearedEvent)
+			}
+		}
+		allianceStation.wasDsLinked = dsLinked
+		allianceStation.wasRobotLinked = robotLinked
+		allianceStation.wasEmergencyStop = allianceStation.EmergencyStop
+		allianceStation.wasAStop = allianceStation.AStop
+		allianceStation.wasBypassed = allianceStation.Bypass
+		if len(eventTypes) == 0 {
+			continue
+		}
+
+		now := time.Now()
+		duringMatch := arena.MatchState > PRE_MATCH && arena.MatchState < POST_MATCH
+		for _, eventType := range connectionEventTypes {
+			connectionEvent := ConnectionEvent{TeamId: allianceStation.Team.Id, AllianceStation: station,
+				MatchId: arena.currentMatch.Id, MatchType: arena.currentMatch.Type,
+				MatchDisplayName: arena.currentMatch.DisplayName, DuringMatch: duringMatch, EventType: eventType,
+				Time: now}
+			if err := db.CreateConnectionEvent(&connectionEvent); err != nil {
+				logBuffer.Error(arenaSubsystem, "Failed to record connection event for team %d: %v",
+					allianceStation.Team.Id, err)
+			}
+		}
+
+		// Test matches aren't saved, so there is nothing to attach their events to.
+		if arena.currentMatch.Type == "test" {
+			continue
+		}
+		for _, eventType := range eventTypes {
+			matchEvent := MatchEvent{MatchId: arena.currentMatch.Id, MatchTimeSec: arena.MatchTimeSec(),
+				DuringMatch: duringMatch, AllianceStation: station, TeamId: allianceStation.Team.Id,
+				EventType: eventType, Time: now}
+			if err := db.CreateMatchEvent(&matchEvent); err != nil {
+				logBuffer.Error(arenaSubsystem, "Failed to record match event for team %d: %v",
+					allianceStation.Team.Id, err)
+			}
+		}
+	}
+}
+
 // Calculates the integer score value for the given realtime snapshot.
 func (realtimeScore *RealtimeScore) Score(opponentFouls []Foul) int {
-	return scoreSummary(&realtimeScore.CurrentScore, opponentFouls, mainArena.currentMatch.Type).Score
+	return currentGame().ScoreSummary(&realtimeScore.CurrentScore, opponentFouls, mainArena.currentMatch.Type).Score
 }
 
-// Calculates the integer score, tower strength, and defenses strength for the given realtime snapshot.
-func (realtimeScore *RealtimeScore) ScoreFields(opponentFouls []Foul) *RealtimeScoreFields {
-	scoreSummary := scoreSummary(&realtimeScore.CurrentScore, opponentFouls, mainArena.currentMatch.Type)
-	var defensesStrength [5]int
-	for i := 0; i < 5; i++ {
-		defensesStrength[i] = 2 - realtimeScore.CurrentScore.AutoDefensesCrossed[i] -
-			realtimeScore.CurrentScore.DefensesCrossed[i]
-	}
-	return &RealtimeScoreFields{scoreSummary.Score, scoreSummary.TowerStrength, defensesStrength}
+// Calculates the score summary, including the game-specific fields, for the given realtime snapshot.
+func (realtimeScore *RealtimeScore) ScoreSummary(opponentFouls []Foul) *ScoreSummary {
+	return currentGame().ScoreSummary(&realtimeScore.CurrentScore, opponentFouls, mainArena.currentMatch.Type)
 }
 
 // Manipulates the arena LED lighting based on the current state of the match.
@@ -546,9 +723,14 @@ func (arena *Arena) handleLighting() {
 	case TELEOP_PERIOD:
 		fallthrough
 	case ENDGAME_PERIOD:
-		redScoreFields := arena.redRealtimeScore.ScoreFields(arena.blueRealtimeScore.CurrentScore.Fouls)
-		blueScoreFields := arena.blueRealtimeScore.ScoreFields(arena.redRealtimeScore.CurrentScore.Fouls)
-		arena.lights.SetDefenses(redScoreFields.DefensesStrength, blueScoreFields.DefensesStrength)
+		// The defense lights only have a meaning in Stronghold.
+		redSummary := arena.redRealtimeScore.ScoreSummary(arena.blueRealtimeScore.CurrentScore.Fouls)
+		blueSummary := arena.blueRealtimeScore.ScoreSummary(arena.redRealtimeScore.CurrentScore.Fouls)
+		redStrongholdSummary, redOk := redSummary.GameSummary.(StrongholdScoreSummary)
+		blueStrongholdSummary, blueOk := blueSummary.GameSummary.(StrongholdScoreSummary)
+		if redOk && blueOk {
+			arena.lights.SetDefenses(redStrongholdSummary.DefensesStrength, blueStrongholdSummary.DefensesStrength)
+		}
 	case POST_MATCH:
 		if mainArena.fieldReset {
 			arena.lights.SetFieldReset()
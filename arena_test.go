@@ -465,7 +465,7 @@ func TestSubstituteTeam(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 107, mainArena.currentMatch.Red1)
 	assert.Equal(t, 107, mainArena.AllianceStations["R1"].Team.Id)
-	CommitMatchScore(mainArena.currentMatch, &MatchResult{MatchId: mainArena.currentMatch.Id}, false)
+	CommitMatchScore(mainArena.currentMatch, &MatchResult{MatchId: mainArena.currentMatch.Id}, false, "admin")
 	match2, _ := db.GetMatchById(match.Id)
 	assert.Equal(t, 107, match2.Red1)
 
@@ -504,3 +504,119 @@ func TestSetupNetwork(t *testing.T) {
 	assert.Contains(t, writer.String(), "Failed to configure team Ethernet")
 	assert.Contains(t, writer.String(), "Failed to configure team WiFi")
 }
+
+func TestArenaStationStops(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+	mainArena = Arena{}
+	mainArena.Setup()
+	db.CreateTeam(&Team{Id: 254})
+	match := Match{Type: "practice", DisplayName: "1", Red1: 254}
+	db.CreateMatch(&match)
+	assert.Nil(t, mainArena.LoadMatch(&match))
+	mainArena.AllianceStations["R1"].DsConn = &DriverStationConnection{TeamId: 254, RobotLinked: true}
+	for _, station := range []string{"R2", "R3", "B1", "B2", "B3"} {
+		mainArena.AllianceStations[station].Bypass = true
+	}
+
+	err = mainArena.EmergencyStopStation("R4", "FTA")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "Invalid alliance station")
+	}
+	err = mainArena.AStopStation("R4", "FTA")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "Invalid alliance station")
+	}
+
+	// An A-stop should keep the robot disabled for the autonomous period only.
+	assert.Nil(t, mainArena.AStopStation("R1", "FTA"))
+	assert.Nil(t, mainArena.StartMatch())
+	mainArena.Update()
+	assert.Equal(t, AUTO_PERIOD, mainArena.MatchState)
+	assert.Equal(t, true, mainArena.AllianceStations["R1"].DsConn.Auto)
+	assert.Equal(t, false, mainArena.AllianceStations["R1"].DsConn.Enabled)
+	mainArena.matchStartTime = time.Now().Add(-time.Duration(mainArena.matchTiming.AutoDurationSec+
+		mainArena.matchTiming.PauseDurationSec) * time.Second)
+	mainArena.Update()
+	mainArena.Update()
+	assert.Equal(t, TELEOP_PERIOD, mainArena.MatchState)
+	assert.Equal(t, true, mainArena.AllianceStations["R1"].DsConn.Enabled)
+	assert.Equal(t, true, mainArena.AllianceStations["R1"].AStop)
+	err = mainArena.AStopStation("R1", "FTA")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "after the autonomous period")
+	}
+
+	// An e-stop should disable the robot immediately and for the rest of the match.
+	assert.Nil(t, mainArena.EmergencyStopStation("R1", "FTA"))
+	assert.True(t, mainArena.lastDsPacketTime.IsZero())
+	mainArena.Update()
+	assert.Equal(t, false, mainArena.AllianceStations["R1"].DsConn.Enabled)
+	assert.Equal(t, true, mainArena.AllianceStations["R1"].DsConn.EmergencyStop)
+	entries := logBuffer.Entries()
+	assert.Equal(t, "Emergency stop activated for station R1 (Team 254) by FTA.", entries[len(entries)-1].Message)
+	matchEvents, err := db.GetMatchEventsByMatchId(match.Id)
+	assert.Nil(t, err)
+	var eventTypes []string
+	for _, matchEvent := range matchEvents {
+		eventTypes = append(eventTypes, matchEvent.EventType)
+	}
+	assert.Contains(t, eventTypes, aStopEvent)
+	assert.Contains(t, eventTypes, emergencyStopEvent)
+
+	// The stops should only be cleared by resetting the match.
+	assert.Nil(t, mainArena.AbortMatch())
+	mainArena.Update()
+	assert.Equal(t, true, mainArena.AllianceStations["R1"].EmergencyStop)
+	assert.Nil(t, mainArena.ResetMatch())
+	assert.Equal(t, false, mainArena.AllianceStations["R1"].EmergencyStop)
+	assert.Equal(t, false, mainArena.AllianceStations["R1"].AStop)
+}
+
+func TestArenaPreMatchStationStops(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+	mainArena = Arena{}
+	mainArena.Setup()
+	match := Match{Type: "practice", DisplayName: "1"}
+	db.CreateMatch(&match)
+	assert.Nil(t, mainArena.LoadMatch(&match))
+	for _, allianceStation := range mainArena.AllianceStations {
+		allianceStation.Bypass = true
+	}
+
+	// An e-stop raised before the match should block it from starting, even after another match is loaded.
+	assert.Nil(t, mainArena.EmergencyStopStation("B3", "FTA"))
+	assert.Nil(t, mainArena.AStopStation("R2", "FTA"))
+	match2 := Match{Type: "practice", DisplayName: "2"}
+	db.CreateMatch(&match2)
+	assert.Nil(t, mainArena.LoadMatch(&match2))
+	assert.Equal(t, true, mainArena.AllianceStations["B3"].EmergencyStop)
+	assert.Equal(t, true, mainArena.AllianceStations["R2"].AStop)
+	for _, allianceStation := range mainArena.AllianceStations {
+		allianceStation.Bypass = true
+	}
+	err = mainArena.StartMatch()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "emergency stop is active")
+	}
+
+	// Resetting the match is the only way to clear the stops.
+	assert.Nil(t, mainArena.ResetMatch())
+	assert.Equal(t, false, mainArena.AllianceStations["B3"].EmergencyStop)
+	assert.Equal(t, false, mainArena.AllianceStations["R2"].AStop)
+	for _, allianceStation := range mainArena.AllianceStations {
+		allianceStation.Bypass = true
+	}
+	assert.Nil(t, mainArena.StartMatch())
+}
@@ -54,6 +54,10 @@ func AudienceDisplayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 	defer close(matchLoadTeamsListener)
 	matchTimeListener := mainArena.matchTimeNotifier.Listen()
 	defer close(matchTimeListener)
+	matchTimingListener := mainArena.matchTimingNotifier.Listen()
+	defer close(matchTimingListener)
+	timeoutListener := mainArena.timeoutNotifier.Listen()
+	defer close(timeoutListener)
 	realtimeScoreListener := mainArena.realtimeScoreNotifier.Listen()
 	defer close(realtimeScoreListener)
 	scorePostedListener := mainArena.scorePostedNotifier.Listen()
@@ -62,6 +66,8 @@ func AudienceDisplayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 	defer close(playSoundListener)
 	allianceSelectionListener := mainArena.allianceSelectionNotifier.Listen()
 	defer close(allianceSelectionListener)
+	bracketListener := mainArena.bracketNotifier.Listen()
+	defer close(bracketListener)
 	lowerThirdListener := mainArena.lowerThirdNotifier.Listen()
 	defer close(lowerThirdListener)
 	reloadDisplaysListener := mainArena.reloadDisplaysNotifier.Listen()
@@ -74,6 +80,11 @@ func AudienceDisplayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 		log.Printf("Websocket error: %s", err)
 		return
 	}
+	err = websocket.Write("timeout", mainArena.TimeoutStatus())
+	if err != nil {
+		log.Printf("Websocket error: %s", err)
+		return
+	}
 	err = websocket.Write("matchTime", MatchTimeMessage{mainArena.MatchState, int(mainArena.lastMatchTimeSec)})
 	if err != nil {
 		log.Printf("Websocket error: %s", err)
@@ -94,10 +105,10 @@ func AudienceDisplayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	data = struct {
-		RedScoreFields  *RealtimeScoreFields
-		BlueScoreFields *RealtimeScoreFields
-	}{mainArena.redRealtimeScore.ScoreFields(mainArena.blueRealtimeScore.CurrentScore.Fouls),
-		mainArena.blueRealtimeScore.ScoreFields(mainArena.redRealtimeScore.CurrentScore.Fouls)}
+		RedScoreFields  *ScoreSummary
+		BlueScoreFields *ScoreSummary
+	}{mainArena.redRealtimeScore.ScoreSummary(mainArena.blueRealtimeScore.CurrentScore.Fouls),
+		mainArena.blueRealtimeScore.ScoreSummary(mainArena.redRealtimeScore.CurrentScore.Fouls)}
 	err = websocket.Write("realtimeScore", data)
 	if err != nil {
 		log.Printf("Websocket error: %s", err)
@@ -120,6 +131,16 @@ func AudienceDisplayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 		log.Printf("Websocket error: %s", err)
 		return
 	}
+	bracket, err := db.GetPlayoffBracket()
+	if err != nil {
+		log.Printf("Websocket error: %s", err)
+		return
+	}
+	err = websocket.Write("bracket", bracket)
+	if err != nil {
+		log.Printf("Websocket error: %s", err)
+		return
+	}
 
 	// Spin off a goroutine to listen for notifications and pass them on through the websocket.
 	go func() {
@@ -148,16 +169,28 @@ func AudienceDisplayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 				}
 				messageType = "matchTime"
 				message = MatchTimeMessage{mainArena.MatchState, matchTimeSec.(int)}
+			case _, ok := <-matchTimingListener:
+				if !ok {
+					return
+				}
+				messageType = "matchTiming"
+				message = mainArena.matchTiming
+			case _, ok := <-timeoutListener:
+				if !ok {
+					return
+				}
+				messageType = "timeout"
+				message = mainArena.TimeoutStatus()
 			case _, ok := <-realtimeScoreListener:
 				if !ok {
 					return
 				}
 				messageType = "realtimeScore"
 				message = struct {
-					RedScoreFields  *RealtimeScoreFields
-					BlueScoreFields *RealtimeScoreFields
-				}{mainArena.redRealtimeScore.ScoreFields(mainArena.blueRealtimeScore.CurrentScore.Fouls),
-					mainArena.blueRealtimeScore.ScoreFields(mainArena.redRealtimeScore.CurrentScore.Fouls)}
+					RedScoreFields  *ScoreSummary
+					BlueScoreFields *ScoreSummary
+				}{mainArena.redRealtimeScore.ScoreSummary(mainArena.blueRealtimeScore.CurrentScore.Fouls),
+					mainArena.blueRealtimeScore.ScoreSummary(mainArena.redRealtimeScore.CurrentScore.Fouls)}
 			case _, ok := <-scorePostedListener:
 				if !ok {
 					return
@@ -182,6 +215,17 @@ func AudienceDisplayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 				}
 				messageType = "allianceSelection"
 				message = cachedAlliances
+			case _, ok := <-bracketListener:
+				if !ok {
+					return
+				}
+				bracket, err := db.GetPlayoffBracket()
+				if err != nil {
+					log.Printf("Failed to build playoff bracket: %s", err)
+					continue
+				}
+				messageType = "bracket"
+				message = bracket
 			case lowerThird, ok := <-lowerThirdListener:
 				if !ok {
 					return
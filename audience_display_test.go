@@ -44,12 +44,14 @@ func TestAudienceDisplayWebsocket(t *testing.T) {
 
 	// Should get a few status updates right after connection.
 	readWebsocketType(t, ws, "matchTiming")
+	readWebsocketType(t, ws, "timeout")
 	readWebsocketType(t, ws, "matchTime")
 	readWebsocketType(t, ws, "setAudienceDisplay")
 	readWebsocketType(t, ws, "setMatch")
 	readWebsocketType(t, ws, "realtimeScore")
 	readWebsocketType(t, ws, "setFinalScore")
 	readWebsocketType(t, ws, "allianceSelection")
+	readWebsocketType(t, ws, "bracket")
 
 	// Run through a match cycle.
 	mainArena.matchLoadTeamsNotifier.Notify(nil)
@@ -81,6 +83,8 @@ func TestAudienceDisplayWebsocket(t *testing.T) {
 	// Test other overlays.
 	mainArena.allianceSelectionNotifier.Notify(nil)
 	readWebsocketType(t, ws, "allianceSelection")
+	mainArena.bracketNotifier.Notify(nil)
+	readWebsocketType(t, ws, "bracket")
 	mainArena.lowerThirdNotifier.Notify(nil)
 	readWebsocketType(t, ws, "lowerThird")
 }
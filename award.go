@@ -0,0 +1,134 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore CRUD methods for an award given out at the event.
+
+package main
+
+import "fmt"
+
+type Award struct {
+	Id         int
+	AwardName  string
+	TeamId     int
+	PersonName string
+}
+
+func (database *Database) CreateAward(award *Award) error {
+	return database.awardMap.Insert(award)
+}
+
+func (database *Database) GetAwardById(id int) (*Award, error) {
+	award := new(Award)
+	err := database.awardMap.Get(award, id)
+	if err != nil && err.Error() == "sql: no rows in result set" {
+		award = nil
+		err = nil
+	}
+	return award, err
+}
+
+func (database *Database) SaveAward(award *Award) error {
+	_, err := database.awardMap.Update(award)
+	return err
+}
+
+func (database *Database) DeleteAward(award *Award) error {
+	_, err := database.awardMap.Delete(award)
+	return err
+}
+
+func (database *Database) TruncateAwards() error {
+	return database.awardMap.TruncateTables()
+}
+
+func (database *Database) GetAllAwards() ([]Award, error) {
+	var awards []Award
+	err := database.awardMap.Select(&awards, "SELECT * FROM awards ORDER BY id")
+	return awards, err
+}
+
+// Returns the text naming who the award goes to, or an empty string if no recipient has been set.
+func (database *Database) GetAwardRecipientText(award *Award) (string, error) {
+	if award.TeamId == 0 {
+		return award.PersonName, nil
+	}
+
+	teamText := fmt.Sprintf("Team %d", award.TeamId)
+	team, err := database.GetTeamById(award.TeamId)
+	if err != nil {
+		return "", err
+	}
+	if team != nil && team.Nickname != "" {
+		teamText += " " + team.Nickname
+	}
+	if award.PersonName != "" {
+		return fmt.Sprintf("%s - %s", award.PersonName, teamText), nil
+	}
+	return teamText, nil
+}
+
+// Creates or updates the lower thirds used to present the given award: one introducing the award and, if a
+// recipient has been set, one announcing them. New lower thirds are added to the end of the list.
+func (database *Database) UpdateAwardLowerThirds(award *Award) error {
+	recipientText, err := database.GetAwardRecipientText(award)
+	if err != nil {
+		return err
+	}
+	newLowerThirds := []LowerThird{{TopText: award.AwardName, AwardId: award.Id}}
+	if recipientText != "" {
+		newLowerThirds = append(newLowerThirds,
+			LowerThird{TopText: award.AwardName, BottomText: recipientText, AwardId: award.Id})
+	}
+
+	oldLowerThirds, err := database.GetLowerThirdsByAwardId(award.Id)
+	if err != nil {
+		return err
+	}
+	allLowerThirds, err := database.GetAllLowerThirds()
+	if err != nil {
+		return err
+	}
+	nextDisplayOrder := 0
+	if len(allLowerThirds) > 0 {
+		nextDisplayOrder = allLowerThirds[len(allLowerThirds)-1].DisplayOrder + 1
+	}
+
+	for i, lowerThird := range newLowerThirds {
+		if i < len(oldLowerThirds) {
+			// Keep the existing record so that its position in the list is preserved.
+			lowerThird.Id = oldLowerThirds[i].Id
+			lowerThird.DisplayOrder = oldLowerThirds[i].DisplayOrder
+			err = database.SaveLowerThird(&lowerThird)
+		} else {
+			lowerThird.DisplayOrder = nextDisplayOrder
+			nextDisplayOrder++
+			err = database.CreateLowerThird(&lowerThird)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for i := len(newLowerThirds); i < len(oldLowerThirds); i++ {
+		err = database.DeleteLowerThird(&oldLowerThirds[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deletes the given award along with the lower thirds that were created for it.
+func (database *Database) DeleteAwardAndLowerThirds(award *Award) error {
+	lowerThirds, err := database.GetLowerThirdsByAwardId(award.Id)
+	if err != nil {
+		return err
+	}
+	for _, lowerThird := range lowerThirds {
+		err = database.DeleteLowerThird(&lowerThird)
+		if err != nil {
+			return err
+		}
+	}
+	return database.DeleteAward(award)
+}
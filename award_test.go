@@ -0,0 +1,120 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetNonexistentAward(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	db, err := OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+
+	award, err := db.GetAwardById(1114)
+	assert.Nil(t, err)
+	assert.Nil(t, award)
+}
+
+func TestAwardCrud(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	db, err := OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+
+	award := Award{0, "Winner", 254, ""}
+	db.CreateAward(&award)
+	award2, err := db.GetAwardById(1)
+	assert.Nil(t, err)
+	assert.Equal(t, award, *award2)
+
+	award.PersonName = "Travis Covington"
+	db.SaveAward(&award)
+	award2, err = db.GetAwardById(1)
+	assert.Nil(t, err)
+	assert.Equal(t, award.PersonName, award2.PersonName)
+
+	db.DeleteAward(&award)
+	award2, err = db.GetAwardById(1)
+	assert.Nil(t, err)
+	assert.Nil(t, award2)
+}
+
+func TestTruncateAwards(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	db, err := OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+
+	db.CreateAward(&Award{0, "Winner", 254, ""})
+	db.TruncateAwards()
+	award, err := db.GetAwardById(1)
+	assert.Nil(t, err)
+	assert.Nil(t, award)
+}
+
+func TestAwardRecipientText(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	db, err := OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	db.CreateTeam(&Team{Id: 254, Nickname: "The Cheesy Poofs"})
+
+	text, _ := db.GetAwardRecipientText(&Award{AwardName: "Winner"})
+	assert.Equal(t, "", text)
+	text, _ = db.GetAwardRecipientText(&Award{AwardName: "Volunteer of the Year", PersonName: "Bob"})
+	assert.Equal(t, "Bob", text)
+	text, _ = db.GetAwardRecipientText(&Award{AwardName: "Winner", TeamId: 254})
+	assert.Equal(t, "Team 254 The Cheesy Poofs", text)
+	text, _ = db.GetAwardRecipientText(&Award{AwardName: "Winner", TeamId: 1114})
+	assert.Equal(t, "Team 1114", text)
+	text, _ = db.GetAwardRecipientText(&Award{AwardName: "Dean's List", TeamId: 254, PersonName: "Alice"})
+	assert.Equal(t, "Alice - Team 254 The Cheesy Poofs", text)
+}
+
+func TestUpdateAwardLowerThirds(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	db, err := OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	db.CreateLowerThird(&LowerThird{0, "Existing", "", 4, 0})
+
+	award := Award{AwardName: "Winner"}
+	db.CreateAward(&award)
+	assert.Nil(t, db.UpdateAwardLowerThirds(&award))
+	lowerThirds, _ := db.GetLowerThirdsByAwardId(award.Id)
+	if assert.Equal(t, 1, len(lowerThirds)) {
+		assert.Equal(t, LowerThird{2, "Winner", "", 5, 1}, lowerThirds[0])
+	}
+
+	// Adding a recipient should keep the existing lower third and add another after it.
+	award.TeamId = 254
+	assert.Nil(t, db.UpdateAwardLowerThirds(&award))
+	lowerThirds, _ = db.GetLowerThirdsByAwardId(award.Id)
+	if assert.Equal(t, 2, len(lowerThirds)) {
+		assert.Equal(t, LowerThird{2, "Winner", "", 5, 1}, lowerThirds[0])
+		assert.Equal(t, LowerThird{3, "Winner", "Team 254", 6, 1}, lowerThirds[1])
+	}
+
+	award.AwardName = "Chairman's"
+	award.TeamId = 0
+	assert.Nil(t, db.UpdateAwardLowerThirds(&award))
+	lowerThirds, _ = db.GetLowerThirdsByAwardId(award.Id)
+	if assert.Equal(t, 1, len(lowerThirds)) {
+		assert.Equal(t, LowerThird{2, "Chairman's", "", 5, 1}, lowerThirds[0])
+	}
+
+	assert.Nil(t, db.DeleteAwardAndLowerThirds(&award))
+	lowerThirds, _ = db.GetAllLowerThirds()
+	if assert.Equal(t, 1, len(lowerThirds)) {
+		assert.Equal(t, "Existing", lowerThirds[0].TopText)
+	}
+}
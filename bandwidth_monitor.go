@@ -8,7 +8,6 @@ package main
 import (
 	"fmt"
 	"github.com/cdevr/WapSNMP"
-	"log"
 	"time"
 )
 
@@ -41,7 +40,7 @@ func MonitorBandwidth() {
 		if eventSettings.NetworkSecurityEnabled && eventSettings.BandwidthMonitoringEnabled {
 			err := monitor.updateBandwidth()
 			if err != nil {
-				log.Printf("Bandwidth monitoring error: %s", err)
+				logBuffer.Warning(bandwidthSubsystem, "Bandwidth monitoring error: %s", err)
 			}
 		}
 		time.Sleep(time.Millisecond * monitoringIntervalMs)
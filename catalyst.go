@@ -50,7 +50,7 @@ func ConfigureTeamEthernet(red1, red2, red3, blue1, blue2, blue3 *Team) error {
 					"access-list 1%d permit udp any eq bootpc any eq bootps\n"+
 					"interface Vlan%d\nip address 10.%d.%d.61 255.255.255.0\n",
 				team.Id/100, team.Id%100, team.Id/100, team.Id%100, vlan, vlan, team.Id/100, team.Id%100, team.Id/100,
-				team.Id%100, vlan, vlan, team.Id/100, team.Id%100, driverStationTcpListenAddress, vlan, vlan,
+				team.Id%100, vlan, vlan, team.Id/100, team.Id%100, dsListeners.Config().ListenAddress, vlan, vlan,
 				team.Id/100, team.Id%100)
 		}
 	}
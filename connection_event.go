@@ -0,0 +1,52 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore CRUD methods for a change in the state of a team's connection to the field.
+
+package main
+
+import (
+	"time"
+)
+
+// Types of connection events.
+const (
+	dsLinkedEvent          = "DS Linked"
+	dsDisconnectedEvent    = "DS Disconnected"
+	robotLinkedEvent       = "Robot Linked"
+	robotDisconnectedEvent = "Robot Disconnected"
+	emergencyStopEvent     = "E-Stop"
+)
+
+type ConnectionEvent struct {
+	Id               int
+	TeamId           int
+	AllianceStation  string
+	MatchId          int
+	MatchType        string
+	MatchDisplayName string
+	DuringMatch      bool
+	EventType        string
+	Time             time.Time
+}
+
+func (database *Database) CreateConnectionEvent(connectionEvent *ConnectionEvent) error {
+	return database.connectionEventMap.Insert(connectionEvent)
+}
+
+func (database *Database) GetConnectionEventsByTeam(teamId int) ([]ConnectionEvent, error) {
+	var connectionEvents []ConnectionEvent
+	err := database.connectionEventMap.Select(&connectionEvents,
+		"SELECT * FROM connection_events WHERE teamid = ? ORDER BY time, id", teamId)
+	return connectionEvents, err
+}
+
+func (database *Database) GetAllConnectionEvents() ([]ConnectionEvent, error) {
+	var connectionEvents []ConnectionEvent
+	err := database.connectionEventMap.Select(&connectionEvents, "SELECT * FROM connection_events ORDER BY time, id")
+	return connectionEvents, err
+}
+
+func (database *Database) TruncateConnectionEvents() error {
+	return database.connectionEventMap.TruncateTables()
+}
@@ -0,0 +1,47 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestConnectionEventCrud(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	db, err := OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+
+	connectionEvents, err := db.GetAllConnectionEvents()
+	assert.Nil(t, err)
+	assert.Empty(t, connectionEvents)
+
+	event1 := ConnectionEvent{0, 254, "R1", 1, "practice", "1", false, dsLinkedEvent, time.Unix(1000, 0).UTC()}
+	event2 := ConnectionEvent{0, 1114, "B1", 1, "practice", "1", true, robotDisconnectedEvent,
+		time.Unix(1001, 0).UTC()}
+	event3 := ConnectionEvent{0, 254, "R1", 1, "practice", "1", true, emergencyStopEvent, time.Unix(1002, 0).UTC()}
+	assert.Nil(t, db.CreateConnectionEvent(&event1))
+	assert.Nil(t, db.CreateConnectionEvent(&event2))
+	assert.Nil(t, db.CreateConnectionEvent(&event3))
+
+	connectionEvents, err = db.GetAllConnectionEvents()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(connectionEvents))
+	connectionEvents, err = db.GetConnectionEventsByTeam(254)
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(connectionEvents)) {
+		assert.Equal(t, event1.EventType, connectionEvents[0].EventType)
+		assert.True(t, event1.Time.Equal(connectionEvents[0].Time))
+		assert.Equal(t, event3.Id, connectionEvents[1].Id)
+		assert.True(t, connectionEvents[1].DuringMatch)
+	}
+
+	db.TruncateConnectionEvents()
+	connectionEvents, err = db.GetAllConnectionEvents()
+	assert.Nil(t, err)
+	assert.Empty(t, connectionEvents)
+}
@@ -0,0 +1,161 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Report of which teams have successfully connected to the field, built from the recorded connection events.
+
+package main
+
+import (
+	"fmt"
+	"github.com/gorilla/mux"
+	"html/template"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Connection statuses that a team can have in the report.
+const (
+	neverConnectedStatus = "Never connected"
+	practiceOnlyStatus   = "Practice only"
+	connectedStatus      = "Connected"
+	midMatchDropsStatus  = "Mid-match drops"
+)
+
+const connectionEventTimeFormat = "Mon 1/02 03:04:05 PM"
+
+// Summary of one team's connection history over the event.
+type TeamConnectionSummary struct {
+	TeamId              int
+	Nickname            string
+	Status              string
+	PracticeMatches     int
+	CompetitionMatches  int
+	MidMatchDrops       int
+	EmergencyStops      int
+	LastRobotLinkedTime time.Time
+}
+
+// Builds a connection summary for every team in the event, plus any others that have connected to the field.
+func (database *Database) GetTeamConnectionSummaries() ([]*TeamConnectionSummary, error) {
+	teams, err := database.GetAllTeams()
+	if err != nil {
+		return nil, err
+	}
+	connectionEvents, err := database.GetAllConnectionEvents()
+	if err != nil {
+		return nil, err
+	}
+
+	var summaries []*TeamConnectionSummary
+	summariesByTeam := make(map[int]*TeamConnectionSummary)
+	for _, team := range teams {
+		summary := &TeamConnectionSummary{TeamId: team.Id, Nickname: team.Nickname}
+		summaries = append(summaries, summary)
+		summariesByTeam[team.Id] = summary
+	}
+
+	linkedMatches := make(map[int]map[string]bool)
+	for _, connectionEvent := range connectionEvents {
+		summary, ok := summariesByTeam[connectionEvent.TeamId]
+		if !ok {
+			summary = &TeamConnectionSummary{TeamId: connectionEvent.TeamId}
+			summaries = append(summaries, summary)
+			summariesByTeam[connectionEvent.TeamId] = summary
+		}
+
+		switch connectionEvent.EventType {
+		case robotLinkedEvent:
+			if linkedMatches[summary.TeamId] == nil {
+				linkedMatches[summary.TeamId] = make(map[string]bool)
+			}
+			matchKey := fmt.Sprintf("%s_%s", connectionEvent.MatchType, connectionEvent.MatchDisplayName)
+			if !linkedMatches[summary.TeamId][matchKey] {
+				linkedMatches[summary.TeamId][matchKey] = true
+				if connectionEvent.MatchType == "qualification" || connectionEvent.MatchType == "elimination" {
+					summary.CompetitionMatches++
+				} else {
+					summary.PracticeMatches++
+				}
+			}
+			summary.LastRobotLinkedTime = connectionEvent.Time
+		case dsDisconnectedEvent, robotDisconnectedEvent:
+			if connectionEvent.DuringMatch {
+				summary.MidMatchDrops++
+			}
+		case emergencyStopEvent:
+			summary.EmergencyStops++
+		}
+	}
+
+	for _, summary := range summaries {
+		if summary.MidMatchDrops > 0 {
+			summary.Status = midMatchDropsStatus
+		} else if summary.CompetitionMatches > 0 {
+			summary.Status = connectedStatus
+		} else if summary.PracticeMatches > 0 {
+			summary.Status = practiceOnlyStatus
+		} else {
+			summary.Status = neverConnectedStatus
+		}
+	}
+	return summaries, nil
+}
+
+// Shows the connection history report for all teams.
+func ConnectionHistoryReportHandler(w http.ResponseWriter, r *http.Request) {
+	if !UserIsReader(w, r) {
+		return
+	}
+
+	summaries, err := db.GetTeamConnectionSummaries()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	template, err := template.ParseFiles("templates/connection_history.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*EventSettings
+		Summaries  []*TeamConnectionSummary
+		TimeFormat string
+	}{eventSettings, summaries, connectionEventTimeFormat}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Shows every connection event recorded for a single team.
+func TeamConnectionHistoryReportHandler(w http.ResponseWriter, r *http.Request) {
+	if !UserIsReader(w, r) {
+		return
+	}
+
+	teamId, _ := strconv.Atoi(mux.Vars(r)["teamId"])
+	connectionEvents, err := db.GetConnectionEventsByTeam(teamId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	template, err := template.ParseFiles("templates/team_connection_history.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*EventSettings
+		TeamId           int
+		ConnectionEvents []ConnectionEvent
+		TimeFormat       string
+	}{eventSettings, teamId, connectionEvents, connectionEventTimeFormat}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRecordStationEvents(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+	db.CreateTeam(&Team{Id: 254})
+	match := Match{Type: "practice", DisplayName: "1", Red1: 254}
+	db.CreateMatch(&match)
+	mainArena.Setup()
+	assert.Nil(t, mainArena.LoadMatch(&match))
+
+	// Nothing should be recorded until something changes.
+	mainArena.recordStationEvents()
+	connectionEvents, _ := db.GetAllConnectionEvents()
+	assert.Empty(t, connectionEvents)
+
+	dsConn := &DriverStationConnection{TeamId: 254, DsLinked: true, RobotLinked: true}
+	mainArena.AllianceStations["R1"].DsConn = dsConn
+	mainArena.recordStationEvents()
+	mainArena.recordStationEvents()
+	connectionEvents, _ = db.GetConnectionEventsByTeam(254)
+	if assert.Equal(t, 2, len(connectionEvents)) {
+		assert.Equal(t, dsLinkedEvent, connectionEvents[0].EventType)
+		assert.Equal(t, robotLinkedEvent, connectionEvents[1].EventType)
+		assert.Equal(t, "R1", connectionEvents[1].AllianceStation)
+		assert.Equal(t, match.Id, connectionEvents[1].MatchId)
+		assert.Equal(t, "practice", connectionEvents[1].MatchType)
+		assert.False(t, connectionEvents[1].DuringMatch)
+	}
+
+	mainArena.MatchState = TELEOP_PERIOD
+	dsConn.RobotLinked = false
+	mainArena.AllianceStations["R1"].EmergencyStop = true
+	mainArena.recordStationEvents()
+	mainArena.AllianceStations["R1"].EmergencyStop = false
+	mainArena.MatchState = PRE_MATCH
+	connectionEvents, _ = db.GetConnectionEventsByTeam(254)
+	if assert.Equal(t, 4, len(connectionEvents)) {
+		assert.Equal(t, robotDisconnectedEvent, connectionEvents[2].EventType)
+		assert.True(t, connectionEvents[2].DuringMatch)
+		assert.Equal(t, emergencyStopEvent, connectionEvents[3].EventType)
+	}
+
+	// Bypassing should only be recorded against the match.
+	mainArena.AllianceStations["R1"].Bypass = true
+	mainArena.recordStationEvents()
+	connectionEvents, _ = db.GetConnectionEventsByTeam(254)
+	assert.Equal(t, 4, len(connectionEvents))
+	matchEvents, _ := db.GetMatchEventsByMatchId(match.Id)
+	if assert.Equal(t, 5, len(matchEvents)) {
+		assert.Equal(t, robotDisconnectedEvent, matchEvents[2].EventType)
+		assert.True(t, matchEvents[2].DuringMatch)
+		assert.Equal(t, 254, matchEvents[2].TeamId)
+		assert.Equal(t, "R1", matchEvents[2].AllianceStation)
+		assert.Equal(t, bypassedEvent, matchEvents[4].EventType)
+		assert.False(t, matchEvents[4].DuringMatch)
+	}
+
+	// Assigning a different team shouldn't record the old one's departure.
+	assert.Nil(t, mainArena.AssignTeam(0, "R1"))
+	mainArena.recordStationEvents()
+	connectionEvents, _ = db.GetAllConnectionEvents()
+	assert.Equal(t, 4, len(connectionEvents))
+
+	// Nothing should be recorded against test matches.
+	mainArena.LoadTestMatch()
+	assert.Nil(t, mainArena.AssignTeam(254, "B1"))
+	mainArena.AllianceStations["B1"].DsConn = &DriverStationConnection{TeamId: 254, DsLinked: true}
+	mainArena.recordStationEvents()
+	connectionEvents, _ = db.GetAllConnectionEvents()
+	assert.Equal(t, 5, len(connectionEvents))
+	matchEvents, _ = db.GetMatchEventsByMatchId(0)
+	assert.Empty(t, matchEvents)
+}
+
+func TestTeamConnectionSummaries(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+	db.CreateTeam(&Team{Id: 254, Nickname: "The Cheesy Poofs"})
+	db.CreateTeam(&Team{Id: 1114})
+	db.CreateTeam(&Team{Id: 2056})
+	db.CreateTeam(&Team{Id: 604})
+	createEvent := func(teamId int, matchType, matchName string, duringMatch bool, eventType string) {
+		db.CreateConnectionEvent(&ConnectionEvent{TeamId: teamId, MatchType: matchType, MatchDisplayName: matchName,
+			DuringMatch: duringMatch, EventType: eventType, Time: time.Now()})
+	}
+	createEvent(254, "practice", "1", false, robotLinkedEvent)
+	createEvent(254, "qualification", "1", false, robotLinkedEvent)
+	createEvent(254, "qualification", "1", false, robotDisconnectedEvent)
+	createEvent(254, "qualification", "1", false, robotLinkedEvent)
+	createEvent(1114, "test", "", false, dsLinkedEvent)
+	createEvent(1114, "practice", "2", false, robotLinkedEvent)
+	createEvent(604, "qualification", "2", false, robotLinkedEvent)
+	createEvent(604, "qualification", "2", true, dsDisconnectedEvent)
+	createEvent(604, "qualification", "2", true, emergencyStopEvent)
+	createEvent(9999, "test", "", false, dsLinkedEvent)
+
+	summaries, err := db.GetTeamConnectionSummaries()
+	assert.Nil(t, err)
+	if assert.Equal(t, 5, len(summaries)) {
+		assert.Equal(t, connectedStatus, summaries[0].Status)
+		assert.Equal(t, 1, summaries[0].PracticeMatches)
+		assert.Equal(t, 1, summaries[0].CompetitionMatches)
+		assert.Equal(t, 0, summaries[0].MidMatchDrops)
+		assert.False(t, summaries[0].LastRobotLinkedTime.IsZero())
+		assert.Equal(t, midMatchDropsStatus, summaries[1].Status)
+		assert.Equal(t, 1, summaries[1].MidMatchDrops)
+		assert.Equal(t, 1, summaries[1].EmergencyStops)
+		assert.Equal(t, practiceOnlyStatus, summaries[2].Status)
+		assert.Equal(t, neverConnectedStatus, summaries[3].Status)
+		assert.Equal(t, 9999, summaries[4].TeamId)
+		assert.Equal(t, neverConnectedStatus, summaries[4].Status)
+	}
+}
+
+func TestConnectionHistoryReport(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+	db.CreateTeam(&Team{Id: 254, Nickname: "The Cheesy Poofs"})
+	db.CreateTeam(&Team{Id: 1114})
+	db.CreateConnectionEvent(&ConnectionEvent{TeamId: 254, AllianceStation: "B2", MatchType: "qualification",
+		MatchDisplayName: "37", EventType: robotLinkedEvent, Time: time.Now()})
+
+	recorder := getHttpResponse("/reports/connection_history")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "The Cheesy Poofs")
+	assert.Contains(t, recorder.Body.String(), "Never connected")
+	assert.Contains(t, recorder.Body.String(), "/reports/connection_history/254")
+
+	recorder = getHttpResponse("/reports/connection_history/254")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "qualification 37")
+	assert.Contains(t, recorder.Body.String(), "Robot Linked")
+}
@@ -21,16 +21,22 @@ const backupsDir = "db/backups"
 const migrationsDir = "db/migrations"
 
 type Database struct {
-	path             string
-	db               *sql.DB
-	eventSettingsMap *modl.DbMap
-	matchMap         *modl.DbMap
-	matchResultMap   *modl.DbMap
-	rankingMap       *modl.DbMap
-	teamMap          *modl.DbMap
-	allianceTeamMap  *modl.DbMap
-	lowerThirdMap    *modl.DbMap
-	sponsorSlideMap  *modl.DbMap
+	path                  string
+	db                    *sql.DB
+	eventSettingsMap      *modl.DbMap
+	matchMap              *modl.DbMap
+	matchResultMap        *modl.DbMap
+	matchResultHistoryMap *modl.DbMap
+	rankingMap            *modl.DbMap
+	teamMap               *modl.DbMap
+	allianceTeamMap       *modl.DbMap
+	allianceTimeoutMap    *modl.DbMap
+	lowerThirdMap         *modl.DbMap
+	sponsorSlideMap       *modl.DbMap
+	scheduleBlockMap      *modl.DbMap
+	awardMap              *modl.DbMap
+	connectionEventMap    *modl.DbMap
+	matchEventMap         *modl.DbMap
 }
 
 // Opens the SQLite database at the given path, creating it if it doesn't exist, and runs any pending
@@ -99,8 +105,11 @@ func (database *Database) mapTables() {
 	database.matchResultMap = modl.NewDbMap(database.db, dialect)
 	database.matchResultMap.AddTableWithName(MatchResultDb{}, "match_results").SetKeys(true, "Id")
 
+	database.matchResultHistoryMap = modl.NewDbMap(database.db, dialect)
+	database.matchResultHistoryMap.AddTableWithName(MatchResultHistory{}, "match_result_history").SetKeys(true, "Id")
+
 	database.rankingMap = modl.NewDbMap(database.db, dialect)
-	database.rankingMap.AddTableWithName(Ranking{}, "rankings").SetKeys(false, "TeamId")
+	database.rankingMap.AddTableWithName(RankingDb{}, "rankings").SetKeys(false, "TeamId")
 
 	database.teamMap = modl.NewDbMap(database.db, dialect)
 	database.teamMap.AddTableWithName(Team{}, "teams").SetKeys(false, "Id")
@@ -108,9 +117,24 @@ func (database *Database) mapTables() {
 	database.allianceTeamMap = modl.NewDbMap(database.db, dialect)
 	database.allianceTeamMap.AddTableWithName(AllianceTeam{}, "alliance_teams").SetKeys(true, "Id")
 
+	database.allianceTimeoutMap = modl.NewDbMap(database.db, dialect)
+	database.allianceTimeoutMap.AddTableWithName(AllianceTimeout{}, "alliance_timeouts").SetKeys(true, "Id")
+
 	database.lowerThirdMap = modl.NewDbMap(database.db, dialect)
 	database.lowerThirdMap.AddTableWithName(LowerThird{}, "lower_thirds").SetKeys(true, "Id")
 
 	database.sponsorSlideMap = modl.NewDbMap(database.db, dialect)
 	database.sponsorSlideMap.AddTableWithName(SponsorSlide{}, "sponsor_slides").SetKeys(true, "Id")
+
+	database.scheduleBlockMap = modl.NewDbMap(database.db, dialect)
+	database.scheduleBlockMap.AddTableWithName(ScheduleBlock{}, "schedule_blocks").SetKeys(true, "Id")
+
+	database.awardMap = modl.NewDbMap(database.db, dialect)
+	database.awardMap.AddTableWithName(Award{}, "awards").SetKeys(true, "Id")
+
+	database.connectionEventMap = modl.NewDbMap(database.db, dialect)
+	database.connectionEventMap.AddTableWithName(ConnectionEvent{}, "connection_events").SetKeys(true, "Id")
+
+	database.matchEventMap = modl.NewDbMap(database.db, dialect)
+	database.matchEventMap.AddTableWithName(MatchEvent{}, "match_events").SetKeys(true, "Id")
 }
@@ -26,7 +26,22 @@ CREATE TABLE event_settings (
   bluedefenselightsaddress VARCHAR(255),
   initialtowerstrength int,
   stemtvpublishingenabled bool,
-  stemtveventcode VARCHAR(16)
+  stemtveventcode VARCHAR(16),
+  game VARCHAR(32),
+  autodurationsec int,
+  pausedurationsec int,
+  teleopdurationsec int,
+  endgametimeleftsec int,
+  fieldresetwarningsec int,
+  fieldresetlimitsec int,
+  elimtype VARCHAR(16),
+  roundrobinpointsrule VARCHAR(16),
+  dsprotocol VARCHAR(16),
+  dslistenaddress VARCHAR(255),
+  dslisteninterface VARCHAR(255),
+  dstcplistenport int,
+  dsudpreceiveport int,
+  dsudpsendport int
 );
 
 -- +goose Down
@@ -3,10 +3,7 @@ CREATE TABLE rankings (
   teamid INTEGER PRIMARY KEY,
   rank int,
   rankingpoints int,
-  autopoints int,
-  scalechallengepoints int,
-  goalpoints int,
-  defensepoints int,
+  tiebreakersjson text,
   random REAL,
   wins int,
   losses int,
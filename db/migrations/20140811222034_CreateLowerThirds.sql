@@ -3,7 +3,8 @@ CREATE TABLE lower_thirds (
   id INTEGER PRIMARY KEY,
   toptext VARCHAR(255),
   bottomtext VARCHAR(255),
-  displayorder int
+  displayorder int,
+  awardid int
 );
 
 -- +goose Down
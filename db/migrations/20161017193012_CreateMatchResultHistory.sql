@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE match_result_history (
+  id INTEGER PRIMARY KEY,
+  matchid int,
+  playnumber int,
+  action VARCHAR(16),
+  user VARCHAR(255),
+  timestamp DATETIME,
+  redscorejson text,
+  bluescorejson text,
+  redcardsjson text,
+  bluecardsjson text
+);
+CREATE INDEX history_matchid ON match_result_history(matchid);
+
+-- +goose Down
+DROP TABLE match_result_history;
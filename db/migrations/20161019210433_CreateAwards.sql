@@ -0,0 +1,10 @@
+-- +goose Up
+CREATE TABLE awards (
+  id INTEGER PRIMARY KEY,
+  awardname VARCHAR(255),
+  teamid int,
+  personname VARCHAR(255)
+);
+
+-- +goose Down
+DROP TABLE awards;
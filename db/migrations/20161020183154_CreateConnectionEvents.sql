@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE connection_events (
+  id INTEGER PRIMARY KEY,
+  teamid int,
+  alliancestation VARCHAR(2),
+  matchid int,
+  matchtype VARCHAR(16),
+  matchdisplayname VARCHAR(16),
+  duringmatch bool,
+  eventtype VARCHAR(32),
+  time DATETIME
+);
+CREATE INDEX connection_events_teamid ON connection_events(teamid);
+
+-- +goose Down
+DROP TABLE connection_events;
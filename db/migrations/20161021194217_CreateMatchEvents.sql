@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE match_events (
+  id INTEGER PRIMARY KEY,
+  matchid int,
+  matchtimesec REAL,
+  duringmatch bool,
+  alliancestation VARCHAR(2),
+  teamid int,
+  eventtype VARCHAR(32),
+  time DATETIME
+);
+CREATE INDEX match_events_matchid ON match_events(matchid);
+
+-- +goose Down
+DROP TABLE match_events;
@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE alliance_timeouts (
+  id INTEGER PRIMARY KEY,
+  allianceid int,
+  matchid int,
+  time DATETIME
+);
+CREATE UNIQUE INDEX alliance_timeout_alliance ON alliance_timeouts(allianceid);
+
+-- +goose Down
+DROP TABLE alliance_timeouts;
@@ -6,14 +6,17 @@
 package main
 
 import (
+	"bufio"
 	"fmt"
-	"log"
+	"io"
 	"net"
+	"strconv"
 	"time"
 )
 
 // FMS uses 1121 for sending UDP packets, and FMS Lite uses 1120. Using 1121
-// seems to work just fine and doesn't prompt to let FMS take control.
+// seems to work just fine and doesn't prompt to let FMS take control. The ports
+// can be overridden in the event settings.
 const (
 	driverStationTcpListenPort     = 1750
 	driverStationUdpSendPort       = 1121
@@ -21,33 +24,76 @@ const (
 	driverStationTcpLinkTimeoutSec = 5
 	driverStationUdpLinkTimeoutSec = 1
 	maxTcpPacketBytes              = 4096
+	maxUdpPacketBytes              = 1500
+	udpStatusHeaderBytes           = 8
 )
 
+// Driver station protocol versions that can be selected in the event settings. The 2015 protocol uses fixed-layout
+// packets, whereas later driver stations append tagged blocks of diagnostic data to their status packets.
+const (
+	legacyDsProtocol  = "2015"
+	taggedDsProtocol  = "tagged"
+	defaultDsProtocol = legacyDsProtocol
+)
+
+var dsProtocols = map[string]string{legacyDsProtocol: "2015 (fixed-layout packets)",
+	taggedDsProtocol: "2016 and later (tagged packets)"}
+
+// Tags identifying the blocks of diagnostic data in a tagged UDP status packet from the DS.
+const (
+	fieldRadioMetricsTag = 0x00
+	commsMetricsTag      = 0x01
+	laptopMetricsTag     = 0x02
+	robotRadioMetricsTag = 0x03
+	pdInfoTag            = 0x04
+)
+
+const maxRecentDsMessages = 10
+
+// Severity levels of the messages that the DS relays from the robot.
+var dsMessageLevels = map[byte]string{0: "info", 1: "warning", 2: "error"}
+
+// A message from the robot code or the DS itself, such as an error or warning printed to the DS console.
+type DsMessage struct {
+	Time         time.Time
+	MatchTimeSec float64
+	Level        string
+	Text         string
+}
+
 type DriverStationConnection struct {
-	TeamId                    int
-	AllianceStation           string
-	Auto                      bool
-	Enabled                   bool
-	EmergencyStop             bool
-	DsLinked                  bool
-	RobotLinked               bool
-	BatteryVoltage            float64
-	DsRobotTripTimeMs         int
-	MissedPacketCount         int
-	MBpsToRobot               float64
-	MBpsFromRobot             float64
-	SecondsSinceLastRobotLink float64
-	lastPacketTime            time.Time
-	lastRobotLinkedTime       time.Time
-	packetCount               int
-	missedPacketOffset        int
-	tcpConn                   net.Conn
-	udpConn                   net.Conn
-	log                       *TeamMatchLog
+	TeamId                         int
+	AllianceStation                string
+	Auto                           bool
+	Enabled                        bool
+	EmergencyStop                  bool
+	DsLinked                       bool
+	RobotLinked                    bool
+	BatteryVoltage                 float64
+	DsRobotTripTimeMs              int
+	MissedPacketCount              int
+	MBpsToRobot                    float64
+	MBpsFromRobot                  float64
+	SecondsSinceLastRobotLink      float64
+	RadioSignalStrength            int
+	RadioBandwidthUtilization      int
+	RobotRadioSignalStrength       int
+	RobotRadioBandwidthUtilization int
+	SentPacketCount                int
+	LaptopBatteryPercent           int
+	LaptopCpuPercent               int
+	DsVersion                      string
+	RecentDsMessages               []DsMessage
+	lastPacketTime                 time.Time
+	lastRobotLinkedTime            time.Time
+	packetCount                    int
+	missedPacketOffset             int
+	tcpConn                        net.Conn
+	udpConn                        net.Conn
+	log                            *TeamMatchLog
 }
 
 var allianceStationPositionMap = map[string]byte{"R1": 0, "R2": 1, "R3": 2, "B1": 3, "B2": 4, "B3": 5}
-var driverStationTcpListenAddress = "10.0.100.5" // The DS will try to connect to this address only.
 
 // Opens a UDP connection for communicating to the driver station.
 func NewDriverStationConnection(teamId int, allianceStation string, tcpConn net.Conn) (*DriverStationConnection, error) {
@@ -55,27 +101,27 @@ func NewDriverStationConnection(teamId int, allianceStation string, tcpConn net.
 	if err != nil {
 		return nil, err
 	}
-	log.Printf("Driver station for Team %d connected from %s\n", teamId, ipAddress)
+	logBuffer.Info(dsSubsystem, "Driver station for Team %d connected from %s", teamId, ipAddress)
 
-	udpConn, err := net.Dial("udp4", fmt.Sprintf("%s:%d", ipAddress, driverStationUdpSendPort))
+	udpConn, err := net.Dial("udp4", fmt.Sprintf("%s:%d", ipAddress, dsListeners.Config().UdpSendPort))
 	if err != nil {
 		return nil, err
 	}
 	return &DriverStationConnection{TeamId: teamId, AllianceStation: allianceStation, tcpConn: tcpConn, udpConn: udpConn}, nil
 }
 
-// Loops indefinitely to read packets and update connection status.
-func ListenForDsUdpPackets() {
-	udpAddress, _ := net.ResolveUDPAddr("udp4", fmt.Sprintf(":%d", driverStationUdpReceivePort))
-	listener, err := net.ListenUDP("udp4", udpAddress)
-	if err != nil {
-		log.Fatalln("Error opening driver station UDP socket: %v", err.Error())
-	}
-	log.Printf("Listening for driver stations on UDP port %d\n", driverStationUdpReceivePort)
-
-	var data [50]byte
+// Loops until the listener is closed to read packets and update connection status.
+func listenForDsUdpPackets(listener *net.UDPConn) {
+	var data [maxUdpPacketBytes]byte
 	for {
-		listener.Read(data[:])
+		n, err := listener.Read(data[:])
+		if err != nil {
+			// The listener has been closed because the network settings changed.
+			return
+		}
+		if n < udpStatusHeaderBytes {
+			continue
+		}
 
 		teamId := int(data[4])<<8 + int(data[5])
 
@@ -98,6 +144,46 @@ func ListenForDsUdpPackets() {
 				// Robot battery voltage, stored as volts * 256.
 				dsConn.BatteryVoltage = float64(data[6]) + float64(data[7])/256
 			}
+
+			if eventSettings.DsProtocol == taggedDsProtocol {
+				dsConn.decodeUdpTags(data[udpStatusHeaderBytes:n])
+			}
+		}
+	}
+}
+
+// Deserializes the tagged blocks of diagnostic data that follow the header of a UDP status packet from the DS.
+func (dsConn *DriverStationConnection) decodeUdpTags(data []byte) {
+	for len(data) > 0 {
+		// Each block is prefixed with its size, which includes the tag byte but not the size byte itself.
+		size := int(data[0])
+		if size == 0 || size >= len(data) {
+			return
+		}
+		tag := data[1]
+		value := data[2 : size+1]
+		data = data[size+1:]
+
+		switch tag {
+		case fieldRadioMetricsTag:
+			if len(value) >= 3 {
+				dsConn.RadioSignalStrength = int(value[0])
+				dsConn.RadioBandwidthUtilization = int(value[1])<<8 + int(value[2])
+			}
+		case commsMetricsTag:
+			if len(value) >= 4 {
+				dsConn.SentPacketCount = int(value[2])<<8 + int(value[3])
+			}
+		case laptopMetricsTag:
+			if len(value) >= 2 {
+				dsConn.LaptopBatteryPercent = int(value[0])
+				dsConn.LaptopCpuPercent = int(value[1])
+			}
+		case robotRadioMetricsTag:
+			if len(value) >= 3 {
+				dsConn.RobotRadioSignalStrength = int(value[0])
+				dsConn.RobotRadioBandwidthUtilization = int(value[1])<<8 + int(value[2])
+			}
 		}
 	}
 }
@@ -182,11 +268,9 @@ func (dsConn *DriverStationConnection) encodeControlPacket() [22]byte {
 	} else {
 		packet[6] = 0
 	}
-	// TODO(patrick): Implement if it ever becomes necessary; the official FMS has a different concept of
-	// match numbers so it's hard to translate.
-	packet[7] = 0 // Match number
-	packet[8] = 1 // Match number
-	packet[9] = 1 // Match repeat number
+	packet[7] = byte(mainArena.dsMatchNumber >> 8 & 0xff) // Match number
+	packet[8] = byte(mainArena.dsMatchNumber & 0xff)      // Match number
+	packet[9] = byte(mainArena.dsRepeatNumber)            // Match repeat number
 
 	// Current time.
 	currentTime := time.Now()
@@ -229,6 +313,43 @@ func (dsConn *DriverStationConnection) encodeControlPacket() [22]byte {
 	return packet
 }
 
+// Returns the match number and repeat number that the driver stations should record in their logs for the given
+// match. Practice and qualification matches keep their scheduled number, while playoff matches are numbered in the
+// order they appear in the schedule since the official FMS has no concept of rounds, groups or instances. The repeat
+// number counts the times the match has been played, including the upcoming play.
+func getDsMatchNumbers(match *Match) (int, int, error) {
+	if match.Id == 0 {
+		// The match isn't in the database, as is the case for test matches.
+		return 1, 1, nil
+	}
+
+	matchNumber := 1
+	if match.Type == "elimination" {
+		matches, err := db.GetMatchesByType(match.Type)
+		if err != nil {
+			return 0, 0, err
+		}
+		for i, elimMatch := range matches {
+			if elimMatch.Id == match.Id {
+				matchNumber = i + 1
+				break
+			}
+		}
+	} else if number, err := strconv.Atoi(match.DisplayName); err == nil && number > 0 {
+		matchNumber = number
+	}
+
+	repeatNumber := 1
+	matchResult, err := db.GetMatchResultForMatch(match.Id)
+	if err != nil {
+		return 0, 0, err
+	}
+	if matchResult != nil {
+		repeatNumber = matchResult.PlayNumber + 1
+	}
+	return matchNumber, repeatNumber, nil
+}
+
 // Builds and sends the next control packet to the Driver Station.
 func (dsConn *DriverStationConnection) sendControlPacket() error {
 	packet := dsConn.encodeControlPacket()
@@ -242,6 +363,30 @@ func (dsConn *DriverStationConnection) sendControlPacket() error {
 	return nil
 }
 
+// Sends a packet of the given type over the TCP connection to the Driver Station.
+func (dsConn *DriverStationConnection) sendTcpPacket(packetType byte, data []byte) error {
+	size := len(data) + 1
+	packet := make([]byte, size+2)
+	packet[0] = byte(size >> 8 & 0xff) // Packet size
+	packet[1] = byte(size & 0xff)      // Packet size
+	packet[2] = packetType
+	copy(packet[3:], data)
+	_, err := dsConn.tcpConn.Write(packet)
+	return err
+}
+
+// Sends the event code to the Driver Station so that it can be included in the team's logs.
+func (dsConn *DriverStationConnection) sendEventCodePacket(eventCode string) error {
+	data := append([]byte{byte(len(eventCode))}, eventCode...)
+	return dsConn.sendTcpPacket(20, data)
+}
+
+// Sends the game-specific data to the Driver Station for the robot code to use.
+func (dsConn *DriverStationConnection) sendGameDataPacket(gameData string) error {
+	data := append([]byte{byte(len(gameData))}, gameData...)
+	return dsConn.sendTcpPacket(28, data)
+}
+
 // Deserializes a packet from the DS into a structure representing the DS/robot status.
 func (dsConn *DriverStationConnection) decodeStatusPacket(data [36]byte) {
 	// Average DS-robot trip time in milliseconds.
@@ -251,33 +396,68 @@ func (dsConn *DriverStationConnection) decodeStatusPacket(data [36]byte) {
 	dsConn.MissedPacketCount = int(data[2]) - dsConn.missedPacketOffset
 }
 
-// Listens for TCP connection requests to Cheesy Arena from driver stations.
-func ListenForDriverStations() {
-	l, err := net.Listen("tcp", fmt.Sprintf("%s:%d", driverStationTcpListenAddress, driverStationTcpListenPort))
-	if err != nil {
-		log.Printf("Error opening driver station TCP socket: %v", err.Error())
-		log.Printf("Change IP address to %s and restart Cheesy Arena to fix.", driverStationTcpListenAddress)
-		return
+// Deserializes a version packet from the DS, which contains a status byte followed by the length-prefixed name and
+// version of a software component, and returns the version.
+func decodeVersionPacket(packet []byte) (string, bool) {
+	if len(packet) < 3 {
+		return "", false
+	}
+	nameLength := int(packet[2])
+	if len(packet) < 4+nameLength {
+		return "", false
 	}
-	defer l.Close()
+	versionLength := int(packet[3+nameLength])
+	if len(packet) < 4+nameLength+versionLength {
+		return "", false
+	}
+	return string(packet[4+nameLength : 4+nameLength+versionLength]), true
+}
+
+// Deserializes a message packet from the DS, which contains the severity level followed by the message text, and
+// adds it to the list of recent messages. Returns nil if the packet is malformed.
+func (dsConn *DriverStationConnection) decodeMessagePacket(packet []byte, matchTimeSec float64) *DsMessage {
+	if len(packet) < 2 {
+		return nil
+	}
+	level, ok := dsMessageLevels[packet[1]]
+	if !ok {
+		level = dsMessageLevels[0]
+	}
+	message := DsMessage{Time: time.Now(), MatchTimeSec: matchTimeSec, Level: level, Text: string(packet[2:])}
 
-	log.Printf("Listening for driver stations on TCP port %d\n", driverStationTcpListenPort)
+	// Copy the list rather than appending in place since it may be concurrently serialized to the displays.
+	messages := append([]DsMessage{}, dsConn.RecentDsMessages...)
+	messages = append(messages, message)
+	if len(messages) > maxRecentDsMessages {
+		messages = messages[len(messages)-maxRecentDsMessages:]
+	}
+	dsConn.RecentDsMessages = messages
+	return &message
+}
+
+// Listens for TCP connection requests to Cheesy Arena from driver stations until the listener is closed.
+func listenForDriverStations(l net.Listener) {
 	for {
 		tcpConn, err := l.Accept()
 		if err != nil {
-			log.Println("Error accepting driver station connection: ", err.Error())
-			continue
+			if netErr, ok := err.(net.Error); ok && netErr.Temporary() {
+				logBuffer.Warning(dsSubsystem, "Error accepting driver station connection: %v", err.Error())
+				continue
+			}
+
+			// The listener has been closed because the network settings changed.
+			return
 		}
 
 		// Read the team number back and start tracking the driver station.
 		var packet [5]byte
 		_, err = tcpConn.Read(packet[:])
 		if err != nil {
-			log.Println("Error reading initial packet: ", err.Error())
+			logBuffer.Warning(dsSubsystem, "Error reading initial packet: %v", err.Error())
 			continue
 		}
 		if !(packet[0] == 0 && packet[1] == 3 && packet[2] == 24) {
-			log.Printf("Invalid initial packet received: %v", packet)
+			logBuffer.Warning(dsSubsystem, "Invalid initial packet received: %v", packet)
 			tcpConn.Close()
 			continue
 		}
@@ -286,7 +466,8 @@ func ListenForDriverStations() {
 		// Check to see if the team is supposed to be on the field, and notify the DS accordingly.
 		assignedStation := mainArena.getAssignedAllianceStation(teamId)
 		if assignedStation == "" {
-			log.Printf("Rejecting connection from Team %d, who is not in the current match, soon.", teamId)
+			logBuffer.Warning(dsSubsystem, "Rejecting connection from Team %d, who is not in the current match, soon.",
+				teamId)
 			go func() {
 				// Wait a second and then close it so it doesn't chew up bandwidth constantly trying to reconnect.
 				time.Sleep(time.Second)
@@ -298,22 +479,33 @@ func ListenForDriverStations() {
 		assignmentPacket[0] = 0  // Packet size
 		assignmentPacket[1] = 3  // Packet size
 		assignmentPacket[2] = 25 // Packet type
-		log.Printf("Accepting connection from Team %d in station %s.", teamId, assignedStation)
+		logBuffer.Info(dsSubsystem, "Accepting connection from Team %d in station %s.", teamId, assignedStation)
 		assignmentPacket[3] = allianceStationPositionMap[assignedStation]
 		assignmentPacket[4] = 0
 		_, err = tcpConn.Write(assignmentPacket[:])
 		if err != nil {
-			log.Println("Error sending driver station assignment packet: %v", err)
+			logBuffer.Error(dsSubsystem, "Error sending driver station assignment packet: %v", err)
 			tcpConn.Close()
 			continue
 		}
 
 		dsConn, err := NewDriverStationConnection(teamId, assignedStation, tcpConn)
 		if err != nil {
-			log.Println("Error registering driver station connection: %v", err)
+			logBuffer.Error(dsSubsystem, "Error registering driver station connection: %v", err)
 			tcpConn.Close()
 			continue
 		}
+		if eventSettings.DsProtocol == taggedDsProtocol {
+			err = dsConn.sendEventCodePacket(eventSettings.Code)
+			if err == nil {
+				err = dsConn.sendGameDataPacket("")
+			}
+			if err != nil {
+				logBuffer.Error(dsSubsystem, "Error sending driver station event information: %v", err)
+				dsConn.Close()
+				continue
+			}
+		}
 		mainArena.AllianceStations[assignedStation].DsConn = dsConn
 
 		// Spin up a goroutine to handle further TCP communication with this driver station.
@@ -322,32 +514,71 @@ func ListenForDriverStations() {
 }
 
 func (dsConn *DriverStationConnection) handleTcpConnection() {
+	// Every packet is prefixed with its two-byte size, but TCP is a stream and a single read may return a partial
+	// packet or several packets at once, so read exactly one packet at a time.
+	reader := bufio.NewReader(dsConn.tcpConn)
 	buffer := make([]byte, maxTcpPacketBytes)
 	for {
 		dsConn.tcpConn.SetReadDeadline(time.Now().Add(time.Second * driverStationTcpLinkTimeoutSec))
-		_, err := dsConn.tcpConn.Read(buffer)
+		packet, err := readTcpPacket(reader, buffer)
 		if err != nil {
-			log.Printf("Error reading from connection for Team %d: %v\n", dsConn.TeamId, err.Error())
+			logBuffer.Warning(dsSubsystem, "Error reading from connection for Team %d: %v", dsConn.TeamId, err.Error())
 			dsConn.Close()
 			mainArena.AllianceStations[dsConn.AllianceStation].DsConn = nil
 			break
 		}
+		dsConn.handleTcpPacket(packet)
+	}
+}
 
-		packetType := int(buffer[2])
-		switch packetType {
-		case 28:
-			// DS keepalive packet; do nothing.
-		case 22:
-			// Robot status packet.
-			var statusPacket [36]byte
-			copy(statusPacket[:], buffer[2:38])
-			dsConn.decodeStatusPacket(statusPacket)
-		}
+// Reads the next size-prefixed packet from the given TCP stream into the buffer, returning it without its size.
+func readTcpPacket(reader io.Reader, buffer []byte) ([]byte, error) {
+	_, err := io.ReadFull(reader, buffer[:2])
+	if err != nil {
+		return nil, err
+	}
+	size := int(buffer[0])<<8 + int(buffer[1])
+	if size > len(buffer) {
+		return nil, fmt.Errorf("Packet size %d exceeds maximum of %d bytes", size, len(buffer))
+	}
+	_, err = io.ReadFull(reader, buffer[:size])
+	if err != nil {
+		return nil, err
+	}
+	return buffer[:size], nil
+}
 
-		// Log the packet if the match is in progress.
-		matchTimeSec := mainArena.MatchTimeSec()
-		if matchTimeSec > 0 && dsConn.log != nil {
-			dsConn.log.LogDsPacket(matchTimeSec, packetType, dsConn)
+// Processes a single packet received over TCP from the Driver Station, starting with its type byte.
+func (dsConn *DriverStationConnection) handleTcpPacket(packet []byte) {
+	if len(packet) == 0 {
+		return
+	}
+	matchTimeSec := mainArena.MatchTimeSec()
+	packetType := int(packet[0])
+	switch packetType {
+	case 28:
+		// DS keepalive packet; do nothing.
+	case 22:
+		// Robot status packet.
+		var statusPacket [36]byte
+		copy(statusPacket[:], packet)
+		dsConn.decodeStatusPacket(statusPacket)
+	case 23:
+		// Error or event message from the robot or the DS; capture it if the match is in progress.
+		message := dsConn.decodeMessagePacket(packet, matchTimeSec)
+		if message != nil && matchTimeSec > 0 && dsConn.log != nil {
+			dsConn.log.LogDsMessage(message)
+		}
+	case 2:
+		// DS version packet. Packet types 0 through 5 carry the versions of the other software components on the
+		// robot, which aren't of interest.
+		if version, ok := decodeVersionPacket(packet); ok {
+			dsConn.DsVersion = version
 		}
 	}
+
+	// Log the packet if the match is in progress.
+	if matchTimeSec > 0 && dsConn.log != nil {
+		dsConn.log.LogDsPacket(matchTimeSec, packetType, dsConn)
+	}
 }
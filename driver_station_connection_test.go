@@ -4,7 +4,9 @@
 package main
 
 import (
+	"bytes"
 	"github.com/stretchr/testify/assert"
+	"io"
 	"net"
 	"testing"
 	"time"
@@ -96,6 +98,13 @@ func TestEncodeControlPacket(t *testing.T) {
 	data = dsConn.encodeControlPacket()
 	assert.Equal(t, byte(3), data[6])
 
+	// Check the match and repeat numbers.
+	assert.Equal(t, []byte{0, 1, 1}, data[7:10])
+	mainArena.dsMatchNumber = 258
+	mainArena.dsRepeatNumber = 3
+	data = dsConn.encodeControlPacket()
+	assert.Equal(t, []byte{1, 2, 3}, data[7:10])
+
 	// Check the countdown at different points during the match.
 	mainArena.MatchState = AUTO_PERIOD
 	mainArena.matchStartTime = time.Now().Add(-time.Duration(4 * time.Second))
@@ -119,6 +128,73 @@ func TestEncodeControlPacket(t *testing.T) {
 	assert.Equal(t, byte(0), data[21])
 }
 
+func TestGetDsMatchNumbers(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+
+	matchNumber, repeatNumber, err := getDsMatchNumbers(&Match{Type: "test"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, matchNumber)
+	assert.Equal(t, 1, repeatNumber)
+
+	practiceMatch := Match{Type: "practice", DisplayName: "7"}
+	db.CreateMatch(&practiceMatch)
+	matchNumber, repeatNumber, err = getDsMatchNumbers(&practiceMatch)
+	assert.Nil(t, err)
+	assert.Equal(t, 7, matchNumber)
+	assert.Equal(t, 1, repeatNumber)
+
+	qualificationMatch := Match{Type: "qualification", DisplayName: "34"}
+	db.CreateMatch(&qualificationMatch)
+	matchNumber, repeatNumber, err = getDsMatchNumbers(&qualificationMatch)
+	assert.Nil(t, err)
+	assert.Equal(t, 34, matchNumber)
+	assert.Equal(t, 1, repeatNumber)
+
+	// Playoff matches should be numbered in the order they are played.
+	sf12 := Match{Type: "elimination", DisplayName: "SF1-2", ElimRound: 2, ElimGroup: 1, ElimInstance: 2}
+	db.CreateMatch(&sf12)
+	sf11 := Match{Type: "elimination", DisplayName: "SF1-1", ElimRound: 2, ElimGroup: 1, ElimInstance: 1}
+	db.CreateMatch(&sf11)
+	sf21 := Match{Type: "elimination", DisplayName: "SF2-1", ElimRound: 2, ElimGroup: 2, ElimInstance: 1}
+	db.CreateMatch(&sf21)
+	f1 := Match{Type: "elimination", DisplayName: "F-1", ElimRound: 1, ElimGroup: 1, ElimInstance: 1}
+	db.CreateMatch(&f1)
+	matchNumber, _, err = getDsMatchNumbers(&sf11)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, matchNumber)
+	matchNumber, _, err = getDsMatchNumbers(&sf21)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, matchNumber)
+	matchNumber, _, err = getDsMatchNumbers(&sf12)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, matchNumber)
+	matchNumber, _, err = getDsMatchNumbers(&f1)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, matchNumber)
+
+	// Replaying a match should increment the repeat number but keep the match number.
+	db.CreateMatchResult(&MatchResult{MatchId: qualificationMatch.Id, PlayNumber: 1})
+	matchNumber, repeatNumber, err = getDsMatchNumbers(&qualificationMatch)
+	assert.Nil(t, err)
+	assert.Equal(t, 34, matchNumber)
+	assert.Equal(t, 2, repeatNumber)
+	db.CreateMatchResult(&MatchResult{MatchId: qualificationMatch.Id, PlayNumber: 2})
+	_, repeatNumber, err = getDsMatchNumbers(&qualificationMatch)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, repeatNumber)
+	db.CreateMatchResult(&MatchResult{MatchId: sf21.Id, PlayNumber: 1})
+	matchNumber, repeatNumber, err = getDsMatchNumbers(&sf21)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, matchNumber)
+	assert.Equal(t, 2, repeatNumber)
+}
+
 func TestSendControlPacket(t *testing.T) {
 	tcpConn := setupFakeTcpConnection(t)
 	defer tcpConn.Close()
@@ -145,6 +221,134 @@ func TestDecodeStatusPacket(t *testing.T) {
 	assert.Equal(t, 14, dsConn.DsRobotTripTimeMs)
 }
 
+func TestDecodeVersionPacket(t *testing.T) {
+	version, ok := decodeVersionPacket([]byte{2, 0, 2, 'D', 'S', 5, '1', '6', '.', '0', '1'})
+	assert.True(t, ok)
+	assert.Equal(t, "16.01", version)
+
+	_, ok = decodeVersionPacket([]byte{2, 0, 2, 'D', 'S', 5, '1', '6'})
+	assert.False(t, ok)
+	_, ok = decodeVersionPacket([]byte{2, 0, 3, 'D'})
+	assert.False(t, ok)
+}
+
+func TestDecodeMessagePacket(t *testing.T) {
+	dsConn := &DriverStationConnection{}
+	dsConn.decodeMessagePacket(append([]byte{23, 2}, "Robot code crashed"...), 12.5)
+	dsConn.decodeMessagePacket(append([]byte{23, 9}, "Joystick unplugged"...), 13)
+	if assert.Equal(t, 2, len(dsConn.RecentDsMessages)) {
+		assert.Equal(t, 12.5, dsConn.RecentDsMessages[0].MatchTimeSec)
+		assert.Equal(t, "error", dsConn.RecentDsMessages[0].Level)
+		assert.Equal(t, "Robot code crashed", dsConn.RecentDsMessages[0].Text)
+		assert.Equal(t, "info", dsConn.RecentDsMessages[1].Level)
+	}
+
+	// Only the most recent messages should be kept.
+	for i := 0; i < maxRecentDsMessages; i++ {
+		dsConn.decodeMessagePacket([]byte{23, 1, byte('a' + i)}, 20)
+	}
+	if assert.Equal(t, maxRecentDsMessages, len(dsConn.RecentDsMessages)) {
+		assert.Equal(t, "a", dsConn.RecentDsMessages[0].Text)
+		assert.Equal(t, "warning", dsConn.RecentDsMessages[0].Level)
+	}
+}
+
+func TestDecodeUdpTags(t *testing.T) {
+	dsConn := &DriverStationConnection{}
+
+	// Field radio, comms, laptop and robot radio metrics, followed by an unknown tag and a truncated block.
+	data := []byte{4, 0, 72, 1, 44, 6, 1, 0, 3, 1, 200, 40, 3, 2, 87, 12, 4, 3, 55, 0, 7, 2, 9, 1, 10, 4, 1}
+	dsConn.decodeUdpTags(data)
+	assert.Equal(t, 72, dsConn.RadioSignalStrength)
+	assert.Equal(t, 300, dsConn.RadioBandwidthUtilization)
+	assert.Equal(t, 456, dsConn.SentPacketCount)
+	assert.Equal(t, 87, dsConn.LaptopBatteryPercent)
+	assert.Equal(t, 12, dsConn.LaptopCpuPercent)
+	assert.Equal(t, 55, dsConn.RobotRadioSignalStrength)
+	assert.Equal(t, 7, dsConn.RobotRadioBandwidthUtilization)
+}
+
+func TestHandleTaggedTcpPackets(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+	eventSettings.DsProtocol = taggedDsProtocol
+	defer func() { eventSettings.DsProtocol = legacyDsProtocol }()
+	mainArena.Setup()
+
+	serverConn, clientConn := net.Pipe()
+	defer clientConn.Close()
+	dsConn := &DriverStationConnection{TeamId: 254, AllianceStation: "R1", tcpConn: serverConn}
+	mainArena.AllianceStations["R1"].DsConn = dsConn
+	go dsConn.handleTcpConnection()
+
+	// A keepalive and a status packet sent in a single write should both be processed.
+	data := []byte{0, 1, 28, 0, 10, 22, 28, 103, 19, 192, 0, 246, 0, 0, 0}
+	_, err = clientConn.Write(data)
+	assert.Nil(t, err)
+	time.Sleep(time.Millisecond * 10)
+	assert.Equal(t, 103, dsConn.MissedPacketCount)
+	assert.Equal(t, 14, dsConn.DsRobotTripTimeMs)
+
+	// Version and message packets should be recorded.
+	data = []byte{0, 11, 2, 0, 2, 'D', 'S', 5, '1', '6', '.', '0', '1', 0, 7, 23, 2, 'E', 'r', 'r', 'o', 'r'}
+	_, err = clientConn.Write(data)
+	assert.Nil(t, err)
+	time.Sleep(time.Millisecond * 10)
+	assert.Equal(t, "16.01", dsConn.DsVersion)
+	if assert.Equal(t, 1, len(dsConn.RecentDsMessages)) {
+		assert.Equal(t, "Error", dsConn.RecentDsMessages[0].Text)
+	}
+
+	// A packet split across two writes should be reassembled.
+	data = []byte{0, 7, 23, 1, 'S', 'p', 'l', 'i', 't'}
+	_, err = clientConn.Write(data[:5])
+	assert.Nil(t, err)
+	time.Sleep(time.Millisecond * 10)
+	assert.Equal(t, 1, len(dsConn.RecentDsMessages))
+	_, err = clientConn.Write(data[5:])
+	assert.Nil(t, err)
+	time.Sleep(time.Millisecond * 10)
+	if assert.Equal(t, 2, len(dsConn.RecentDsMessages)) {
+		assert.Equal(t, "Split", dsConn.RecentDsMessages[1].Text)
+	}
+
+	// Check that the driver station is dropped once the connection is closed.
+	clientConn.Close()
+	time.Sleep(time.Millisecond * 10)
+	assert.Nil(t, mainArena.AllianceStations["R1"].DsConn)
+}
+
+func TestReadTcpPacket(t *testing.T) {
+	buffer := make([]byte, maxTcpPacketBytes)
+
+	// Merged packets should be separated, with none of the following packet's bytes in the first.
+	reader := bytes.NewReader([]byte{0, 4, 23, 0, 'H', 'i', 0, 1, 28})
+	packet, err := readTcpPacket(reader, buffer)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{23, 0, 'H', 'i'}, packet)
+	packet, err = readTcpPacket(reader, buffer)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{28}, packet)
+	_, err = readTcpPacket(reader, buffer)
+	assert.Equal(t, io.EOF, err)
+
+	// A truncated packet should be reported as an error rather than handled.
+	reader = bytes.NewReader([]byte{0, 36, 22, 28})
+	_, err = readTcpPacket(reader, buffer)
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+
+	reader = bytes.NewReader([]byte{0xff, 0xff, 22})
+	_, err = readTcpPacket(reader, buffer)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "exceeds maximum")
+	}
+}
+
 func TestListenForDriverStations(t *testing.T) {
 	clearDb()
 	defer clearDb()
@@ -154,8 +358,7 @@ func TestListenForDriverStations(t *testing.T) {
 	defer db.Close()
 	eventSettings, _ = db.GetEventSettings()
 
-	driverStationTcpListenAddress = "127.0.0.1"
-	go ListenForDriverStations()
+	dsListeners.Restart(DsNetworkConfig{"127.0.0.1", "", 1750, 1160, 1121})
 	mainArena.Setup()
 	time.Sleep(time.Millisecond * 10)
 
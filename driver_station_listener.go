@@ -0,0 +1,179 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Management of the sockets on which Cheesy Arena listens for driver stations, which are rebound whenever the
+// network settings change.
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+)
+
+const defaultDsListenAddress = "10.0.100.5" // The DS will try to connect to this address only.
+
+// Addresses and ports used for communicating with the driver stations.
+type DsNetworkConfig struct {
+	ListenAddress   string
+	ListenInterface string
+	TcpListenPort   int
+	UdpReceivePort  int
+	UdpSendPort     int
+}
+
+// Result of the last attempt to bind the driver station listeners, for display to the FTA.
+type DsListenerStatus struct {
+	Address  string
+	TcpPort  int
+	UdpPort  int
+	TcpBound bool
+	UdpBound bool
+	Error    string
+}
+
+type DsListeners struct {
+	Notifier    *Notifier
+	config      DsNetworkConfig
+	status      DsListenerStatus
+	tcpListener net.Listener
+	udpListener *net.UDPConn
+	mutex       sync.Mutex
+}
+
+var dsListeners = DsListeners{Notifier: NewNotifier(), config: DsNetworkConfig{defaultDsListenAddress, "",
+	driverStationTcpListenPort, driverStationUdpReceivePort, driverStationUdpSendPort}}
+
+// Returns the driver station network settings from the event settings, overridden by any command-line flags.
+func getDsNetworkConfig() DsNetworkConfig {
+	config := DsNetworkConfig{defaultDsListenAddress, "", driverStationTcpListenPort, driverStationUdpReceivePort,
+		driverStationUdpSendPort}
+	if eventSettings != nil {
+		if eventSettings.DsListenAddress != "" {
+			config.ListenAddress = eventSettings.DsListenAddress
+		}
+		config.ListenInterface = eventSettings.DsListenInterface
+		if eventSettings.DsTcpListenPort != 0 {
+			config.TcpListenPort = eventSettings.DsTcpListenPort
+		}
+		if eventSettings.DsUdpReceivePort != 0 {
+			config.UdpReceivePort = eventSettings.DsUdpReceivePort
+		}
+		if eventSettings.DsUdpSendPort != 0 {
+			config.UdpSendPort = eventSettings.DsUdpSendPort
+		}
+	}
+
+	if *dsListenAddressFlag != "" {
+		config.ListenAddress = *dsListenAddressFlag
+		config.ListenInterface = ""
+	}
+	if *dsListenInterfaceFlag != "" {
+		config.ListenInterface = *dsListenInterfaceFlag
+	}
+	if *dsTcpListenPortFlag != 0 {
+		config.TcpListenPort = *dsTcpListenPortFlag
+	}
+	if *dsUdpReceivePortFlag != 0 {
+		config.UdpReceivePort = *dsUdpReceivePortFlag
+	}
+	if *dsUdpSendPortFlag != 0 {
+		config.UdpSendPort = *dsUdpSendPortFlag
+	}
+	return config
+}
+
+// Returns the IP address to bind to, which is the first IPv4 address of the interface if one is specified.
+func (config *DsNetworkConfig) bindAddress() (string, error) {
+	if config.ListenInterface == "" {
+		return config.ListenAddress, nil
+	}
+	networkInterface, err := net.InterfaceByName(config.ListenInterface)
+	if err != nil {
+		return "", fmt.Errorf("Network interface %s not found: %v", config.ListenInterface, err)
+	}
+	addresses, err := networkInterface.Addrs()
+	if err != nil {
+		return "", err
+	}
+	for _, address := range addresses {
+		if ipNet, ok := address.(*net.IPNet); ok && ipNet.IP.To4() != nil {
+			return ipNet.IP.String(), nil
+		}
+	}
+	return "", fmt.Errorf("Network interface %s has no IPv4 address.", config.ListenInterface)
+}
+
+// Closes any existing driver station listeners and opens new ones using the current network settings.
+func RestartDsListeners() {
+	dsListeners.Restart(getDsNetworkConfig())
+}
+
+// Rebinds the driver station listeners using the given network settings. Driver stations that are already connected
+// are unaffected.
+func (listeners *DsListeners) Restart(config DsNetworkConfig) {
+	listeners.mutex.Lock()
+	defer listeners.mutex.Unlock()
+
+	if listeners.tcpListener != nil {
+		listeners.tcpListener.Close()
+		listeners.tcpListener = nil
+	}
+	if listeners.udpListener != nil {
+		listeners.udpListener.Close()
+		listeners.udpListener = nil
+	}
+
+	status := DsListenerStatus{TcpPort: config.TcpListenPort, UdpPort: config.UdpReceivePort}
+	address, err := config.bindAddress()
+	if err != nil {
+		status.Error = err.Error()
+		logBuffer.Error(dsSubsystem, "Error determining driver station listen address: %v", err)
+	} else {
+		config.ListenAddress = address
+		config.ListenInterface = ""
+		status.Address = address
+
+		tcpListener, err := net.Listen("tcp", net.JoinHostPort(address, strconv.Itoa(config.TcpListenPort)))
+		if err != nil {
+			status.Error = err.Error()
+			logBuffer.Error(dsSubsystem, "Error opening driver station TCP socket: %v", err)
+		} else {
+			status.TcpBound = true
+			listeners.tcpListener = tcpListener
+			logBuffer.Info(dsSubsystem, "Listening for driver stations on %s.", tcpListener.Addr())
+			go listenForDriverStations(tcpListener)
+		}
+
+		udpListener, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.ParseIP(address), Port: config.UdpReceivePort})
+		if err != nil {
+			status.Error = err.Error()
+			logBuffer.Error(dsSubsystem, "Error opening driver station UDP socket: %v", err)
+		} else {
+			status.UdpBound = true
+			listeners.udpListener = udpListener
+			logBuffer.Info(dsSubsystem, "Listening for driver station status on UDP %s.", udpListener.LocalAddr())
+			go listenForDsUdpPackets(udpListener)
+		}
+	}
+
+	listeners.config = config
+	listeners.status = status
+	listeners.Notifier.Notify(status)
+}
+
+// Returns the network settings in effect, with the listen address resolved from the interface if necessary.
+func (listeners *DsListeners) Config() DsNetworkConfig {
+	listeners.mutex.Lock()
+	defer listeners.mutex.Unlock()
+	return listeners.config
+}
+
+// Returns the result of the last attempt to bind the listeners.
+func (listeners *DsListeners) Status() DsListenerStatus {
+	listeners.mutex.Lock()
+	defer listeners.mutex.Unlock()
+	return listeners.status
+}
@@ -0,0 +1,91 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net"
+	"testing"
+)
+
+func TestGetDsNetworkConfig(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+
+	assert.Equal(t, DsNetworkConfig{"10.0.100.5", "", 1750, 1160, 1121}, getDsNetworkConfig())
+
+	// Unset values in the event settings should fall back to the defaults.
+	eventSettings.DsListenAddress = ""
+	eventSettings.DsListenInterface = "eth1"
+	eventSettings.DsTcpListenPort = 0
+	eventSettings.DsUdpReceivePort = 1170
+	assert.Equal(t, DsNetworkConfig{"10.0.100.5", "eth1", 1750, 1170, 1121}, getDsNetworkConfig())
+
+	// Command-line flags should take precedence over the event settings.
+	*dsListenAddressFlag = "10.0.100.6"
+	*dsTcpListenPortFlag = 1751
+	defer func() {
+		*dsListenAddressFlag = ""
+		*dsTcpListenPortFlag = 0
+	}()
+	assert.Equal(t, DsNetworkConfig{"10.0.100.6", "", 1751, 1170, 1121}, getDsNetworkConfig())
+}
+
+func TestDsNetworkConfigBindAddress(t *testing.T) {
+	config := DsNetworkConfig{ListenAddress: "10.0.100.5"}
+	address, err := config.bindAddress()
+	assert.Nil(t, err)
+	assert.Equal(t, "10.0.100.5", address)
+
+	config.ListenInterface = "lo"
+	if _, err := net.InterfaceByName("lo"); err == nil {
+		address, err = config.bindAddress()
+		assert.Nil(t, err)
+		assert.Equal(t, "127.0.0.1", address)
+	}
+
+	config.ListenInterface = "blorpy0"
+	_, err = config.bindAddress()
+	assert.NotNil(t, err)
+}
+
+func TestRestartDsListeners(t *testing.T) {
+	defer dsListeners.Restart(DsNetworkConfig{"127.0.0.1", "", 1750, 1160, 1121})
+
+	dsListeners.Restart(DsNetworkConfig{"127.0.0.1", "", 17501, 11601, 1121})
+	assert.Equal(t, DsListenerStatus{"127.0.0.1", 17501, 11601, true, true, ""}, dsListeners.Status())
+	assert.Equal(t, DsNetworkConfig{"127.0.0.1", "", 17501, 11601, 1121}, dsListeners.Config())
+	tcpConn, err := net.Dial("tcp", "127.0.0.1:17501")
+	if assert.Nil(t, err) {
+		tcpConn.Close()
+	}
+
+	// Changing the port should release the old one.
+	dsListeners.Restart(DsNetworkConfig{"127.0.0.1", "", 17502, 11602, 1121})
+	assert.True(t, dsListeners.Status().TcpBound)
+	_, err = net.Dial("tcp", "127.0.0.1:17501")
+	assert.NotNil(t, err)
+	tcpConn, err = net.Dial("tcp", "127.0.0.1:17502")
+	if assert.Nil(t, err) {
+		tcpConn.Close()
+	}
+
+	// An address that doesn't belong to this machine should be reported as unbound.
+	dsListeners.Restart(DsNetworkConfig{"192.0.2.1", "", 17502, 11602, 1121})
+	status := dsListeners.Status()
+	assert.False(t, status.TcpBound)
+	assert.False(t, status.UdpBound)
+	assert.NotEqual(t, "", status.Error)
+
+	dsListeners.Restart(DsNetworkConfig{"127.0.0.1", "blorpy0", 17502, 11602, 1121})
+	status = dsListeners.Status()
+	assert.Equal(t, "", status.Address)
+	assert.False(t, status.TcpBound)
+	assert.Contains(t, status.Error, "blorpy0")
+}
@@ -0,0 +1,418 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Simulator that emulates the driver stations of the teams in the loaded match, for rehearsing match flow without
+// real robots.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	dsSimulatorUdpPeriodMs           = 20
+	dsSimulatorTcpPeriodMs           = 500
+	dsSimulatorConnectRetrySec       = 1
+	dsSimulatorConnectTimeoutMs      = 500
+	dsSimulatorNominalBatteryVoltage = 12.5
+	dsSimulatorLowBatteryVoltage     = 6.5
+	dsSimulatorNominalTripTimeMs     = 3
+	dsSimulatorSpikeTripTimeMs       = 60
+	dsSimulatorLaptopBatteryPercent  = 100
+	dsSimulatorLaptopCpuPercent      = 15
+)
+
+// Types of faults that can be scripted to occur during a simulated match.
+const (
+	dsLinkDropSimEvent    = "ds_link_drop"
+	robotLinkDropSimEvent = "robot_link_drop"
+	lowBatterySimEvent    = "low_battery"
+	tripTimeSpikeSimEvent = "trip_time_spike"
+)
+
+var dsSimulatorEventTypes = map[string]string{dsLinkDropSimEvent: "DS link drop",
+	robotLinkDropSimEvent: "Robot link drop", lowBatterySimEvent: "Low battery",
+	tripTimeSpikeSimEvent: "Trip time spike"}
+
+// A scripted fault affecting the simulated driver station in the given alliance station, timed relative to the
+// start of the match.
+type DsSimulatorEvent struct {
+	AllianceStation string
+	Type            string
+	StartSec        float64
+	DurationSec     float64
+}
+
+type SimulatedDriverStation struct {
+	TeamId             int
+	AllianceStation    string
+	Connected          bool
+	packetCount        int
+	connecting         bool
+	lastConnectAttempt time.Time
+	lastTcpPacketTime  time.Time
+	tcpConn            net.Conn
+	udpConn            net.Conn
+}
+
+type DsSimulator struct {
+	Running         bool
+	Events          []DsSimulatorEvent
+	Stations        map[string]*SimulatedDriverStation
+	config          DsNetworkConfig
+	controlListener *net.UDPConn
+	stop            chan struct{}
+	mutex           sync.Mutex
+}
+
+var dsSimulator DsSimulator
+
+// Starts simulating a driver station for each team in the loaded match, connecting to the FMS using the given
+// network settings.
+func (sim *DsSimulator) Start(config DsNetworkConfig) error {
+	sim.mutex.Lock()
+	defer sim.mutex.Unlock()
+	if sim.Running {
+		return fmt.Errorf("Driver station simulator is already running.")
+	}
+
+	// Absorb the control packets sent by the FMS so that they aren't rejected by the host.
+	controlListener, err := net.ListenUDP("udp4", &net.UDPAddr{Port: config.UdpSendPort})
+	if err != nil {
+		logBuffer.Warning(dsSubsystem, "Driver station simulator couldn't listen for control packets: %v", err)
+	} else {
+		sim.controlListener = controlListener
+		go func() {
+			var data [maxUdpPacketBytes]byte
+			for {
+				if _, err := controlListener.Read(data[:]); err != nil {
+					return
+				}
+			}
+		}()
+	}
+
+	sim.config = config
+	sim.Stations = make(map[string]*SimulatedDriverStation)
+	sim.stop = make(chan struct{})
+	sim.Running = true
+	go sim.run(sim.stop)
+	logBuffer.Info(dsSubsystem, "Started driver station simulator connecting to %s.", config.ListenAddress)
+	return nil
+}
+
+// Stops the simulator and disconnects all of the simulated driver stations.
+func (sim *DsSimulator) Stop() {
+	sim.mutex.Lock()
+	defer sim.mutex.Unlock()
+	if !sim.Running {
+		return
+	}
+
+	close(sim.stop)
+	for _, station := range sim.Stations {
+		station.close()
+	}
+	sim.Stations = nil
+	if sim.controlListener != nil {
+		sim.controlListener.Close()
+		sim.controlListener = nil
+	}
+	sim.Running = false
+	logBuffer.Info(dsSubsystem, "Stopped driver station simulator.")
+}
+
+// Adds a fault to the script for the simulated matches.
+func (sim *DsSimulator) AddEvent(event DsSimulatorEvent) error {
+	if _, ok := allianceStationPositionMap[event.AllianceStation]; !ok {
+		return fmt.Errorf("Invalid alliance station '%s'.", event.AllianceStation)
+	}
+	if _, ok := dsSimulatorEventTypes[event.Type]; !ok {
+		return fmt.Errorf("Invalid simulated event type '%s'.", event.Type)
+	}
+	if event.StartSec < 0 || event.DurationSec <= 0 {
+		return fmt.Errorf("Simulated event must start at a non-negative time and have a positive duration.")
+	}
+
+	sim.mutex.Lock()
+	defer sim.mutex.Unlock()
+	sim.Events = append(sim.Events, event)
+	return nil
+}
+
+// Removes all of the scripted faults.
+func (sim *DsSimulator) ClearEvents() {
+	sim.mutex.Lock()
+	defer sim.mutex.Unlock()
+	sim.Events = nil
+}
+
+// Returns a copy of the simulated driver stations and scripted faults for display.
+func (sim *DsSimulator) Status() ([]SimulatedDriverStation, []DsSimulatorEvent) {
+	sim.mutex.Lock()
+	defer sim.mutex.Unlock()
+	var stations []SimulatedDriverStation
+	for _, allianceStation := range []string{"R1", "R2", "R3", "B1", "B2", "B3"} {
+		if station, ok := sim.Stations[allianceStation]; ok {
+			stations = append(stations, SimulatedDriverStation{TeamId: station.TeamId,
+				AllianceStation: station.AllianceStation, Connected: station.Connected})
+		}
+	}
+	events := make([]DsSimulatorEvent, len(sim.Events))
+	copy(events, sim.Events)
+	return stations, events
+}
+
+// Loops until stopped, sending status packets at the rate of a real driver station.
+func (sim *DsSimulator) run(stop chan struct{}) {
+	ticker := time.NewTicker(time.Millisecond * dsSimulatorUdpPeriodMs)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			sim.update(mainArena.MatchTimeSec())
+		}
+	}
+}
+
+// Brings the set of simulated driver stations in line with the loaded match and sends each one's status.
+func (sim *DsSimulator) update(matchTimeSec float64) {
+	sim.mutex.Lock()
+	defer sim.mutex.Unlock()
+	if !sim.Running {
+		return
+	}
+
+	for allianceStation, arenaStation := range mainArena.AllianceStations {
+		station, ok := sim.Stations[allianceStation]
+		if arenaStation.Team == nil {
+			if ok {
+				station.close()
+				delete(sim.Stations, allianceStation)
+			}
+			continue
+		}
+		if !ok || station.TeamId != arenaStation.Team.Id {
+			if ok {
+				station.close()
+			}
+			station = &SimulatedDriverStation{TeamId: arenaStation.Team.Id, AllianceStation: allianceStation}
+			sim.Stations[allianceStation] = station
+		}
+
+		if sim.isEventActive(allianceStation, dsLinkDropSimEvent, matchTimeSec) {
+			// Go silent; the FMS will time out the link and eventually drop the TCP connection.
+			continue
+		}
+		if !station.Connected {
+			if !station.connecting &&
+				time.Since(station.lastConnectAttempt).Seconds() >= dsSimulatorConnectRetrySec {
+				station.connecting = true
+				station.lastConnectAttempt = time.Now()
+				go sim.connect(station)
+			}
+			continue
+		}
+
+		robotLinked := !sim.isEventActive(allianceStation, robotLinkDropSimEvent, matchTimeSec)
+		batteryVoltage := dsSimulatorNominalBatteryVoltage
+		if sim.isEventActive(allianceStation, lowBatterySimEvent, matchTimeSec) {
+			batteryVoltage = dsSimulatorLowBatteryVoltage
+		}
+		tripTimeMs := dsSimulatorNominalTripTimeMs
+		if sim.isEventActive(allianceStation, tripTimeSpikeSimEvent, matchTimeSec) {
+			tripTimeMs = dsSimulatorSpikeTripTimeMs
+		}
+		if !robotLinked {
+			batteryVoltage = 0
+			tripTimeMs = 0
+		}
+
+		err := station.sendStatus(robotLinked, batteryVoltage, tripTimeMs)
+		if err != nil {
+			logBuffer.Warning(dsSubsystem, "Simulated driver station for Team %d disconnected: %v", station.TeamId,
+				err)
+			station.close()
+		}
+	}
+}
+
+// Returns true if a scripted fault of the given type is in effect for the given station at the given match time.
+func (sim *DsSimulator) isEventActive(allianceStation string, eventType string, matchTimeSec float64) bool {
+	if matchTimeSec <= 0 {
+		return false
+	}
+	for _, event := range sim.Events {
+		if event.AllianceStation == allianceStation && event.Type == eventType && matchTimeSec >= event.StartSec &&
+			matchTimeSec < event.StartSec+event.DurationSec {
+			return true
+		}
+	}
+	return false
+}
+
+// Opens the TCP and UDP connections to the FMS for the given simulated driver station.
+func (sim *DsSimulator) connect(station *SimulatedDriverStation) {
+	tcpConn, udpConn, err := station.dial(sim.config)
+
+	sim.mutex.Lock()
+	defer sim.mutex.Unlock()
+	station.connecting = false
+	if err != nil {
+		return
+	}
+	if sim.Stations[station.AllianceStation] != station {
+		// The simulator was stopped or the team was replaced while connecting.
+		tcpConn.Close()
+		udpConn.Close()
+		return
+	}
+	station.tcpConn = tcpConn
+	station.udpConn = udpConn
+	station.Connected = true
+}
+
+// Performs the driver station side of the connection handshake with the FMS.
+func (station *SimulatedDriverStation) dial(config DsNetworkConfig) (net.Conn, net.Conn, error) {
+	tcpConn, err := net.DialTimeout("tcp", net.JoinHostPort(config.ListenAddress, strconv.Itoa(config.TcpListenPort)),
+		time.Millisecond*dsSimulatorConnectTimeoutMs)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, err = tcpConn.Write([]byte{0, 3, 24, byte(station.TeamId >> 8 & 0xff), byte(station.TeamId & 0xff)})
+	if err != nil {
+		tcpConn.Close()
+		return nil, nil, err
+	}
+
+	// Wait for the station assignment; the FMS closes the connection instead if the team isn't in the match.
+	var assignmentPacket [5]byte
+	tcpConn.SetReadDeadline(time.Now().Add(time.Second * driverStationTcpLinkTimeoutSec))
+	_, err = io.ReadFull(tcpConn, assignmentPacket[:])
+	if err != nil {
+		tcpConn.Close()
+		return nil, nil, err
+	}
+	tcpConn.SetReadDeadline(time.Time{})
+	go func() {
+		// Discard anything else the FMS sends over TCP.
+		var data [maxTcpPacketBytes]byte
+		for {
+			if _, err := tcpConn.Read(data[:]); err != nil {
+				return
+			}
+		}
+	}()
+
+	udpConn, err := net.Dial("udp4", net.JoinHostPort(config.ListenAddress, strconv.Itoa(config.UdpReceivePort)))
+	if err != nil {
+		tcpConn.Close()
+		return nil, nil, err
+	}
+	return tcpConn, udpConn, nil
+}
+
+// Sends a UDP status packet, plus a TCP status packet if one is due.
+func (station *SimulatedDriverStation) sendStatus(robotLinked bool, batteryVoltage float64, tripTimeMs int) error {
+	_, err := station.udpConn.Write(station.encodeUdpStatusPacket(robotLinked, batteryVoltage, tripTimeMs))
+	if err != nil {
+		return err
+	}
+	station.packetCount++
+
+	if time.Since(station.lastTcpPacketTime).Seconds()*1000 >= dsSimulatorTcpPeriodMs {
+		_, err = station.tcpConn.Write(encodeSimulatedTcpStatusPacket(batteryVoltage, tripTimeMs))
+		if err != nil {
+			return err
+		}
+		station.lastTcpPacketTime = time.Now()
+	}
+	return nil
+}
+
+// Serializes the UDP status packet that a driver station sends to the FMS, including the tagged diagnostics.
+func (station *SimulatedDriverStation) encodeUdpStatusPacket(robotLinked bool, batteryVoltage float64,
+	tripTimeMs int) []byte {
+	packet := make([]byte, udpStatusHeaderBytes)
+	packet[0] = byte(station.packetCount >> 8 & 0xff)
+	packet[1] = byte(station.packetCount & 0xff)
+	packet[2] = 0 // Protocol version
+	if robotLinked {
+		packet[3] = 0x20
+	}
+	packet[4] = byte(station.TeamId >> 8 & 0xff)
+	packet[5] = byte(station.TeamId & 0xff)
+	packet[6] = byte(int(batteryVoltage))
+	packet[7] = byte(int((batteryVoltage - float64(int(batteryVoltage))) * 256))
+
+	sentPackets := station.packetCount & 0xffff
+	packet = append(packet, 6, commsMetricsTag, 0, 0, byte(sentPackets>>8), byte(sentPackets&0xff),
+		byte(tripTimeMs))
+	packet = append(packet, 3, laptopMetricsTag, dsSimulatorLaptopBatteryPercent, dsSimulatorLaptopCpuPercent)
+	return packet
+}
+
+// Serializes the TCP robot status packet that a driver station sends to the FMS.
+func encodeSimulatedTcpStatusPacket(batteryVoltage float64, tripTimeMs int) []byte {
+	packet := make([]byte, 38)
+	packet[0] = 0  // Packet size
+	packet[1] = 36 // Packet size
+	packet[2] = 22 // Packet type
+	packet[3] = byte(tripTimeMs * 2)
+	packet[4] = 0 // Lost packets
+	packet[5] = byte(int(batteryVoltage))
+	packet[6] = byte(int((batteryVoltage - float64(int(batteryVoltage))) * 256))
+	return packet
+}
+
+func (station *SimulatedDriverStation) close() {
+	if station.tcpConn != nil {
+		station.tcpConn.Close()
+		station.tcpConn = nil
+	}
+	if station.udpConn != nil {
+		station.udpConn.Close()
+		station.udpConn = nil
+	}
+	station.Connected = false
+}
+
+// Parses a script of simulated faults, one per line in the form "<station> <type> <start sec> <duration sec>".
+// Blank lines and lines starting with '#' are ignored.
+func ParseDsSimulatorScript(reader io.Reader) ([]DsSimulatorEvent, error) {
+	var events []DsSimulatorEvent
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("Line %d: expected '<station> <type> <start sec> <duration sec>'.", lineNumber)
+		}
+		startSec, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("Line %d: invalid start time '%s'.", lineNumber, fields[2])
+		}
+		durationSec, err := strconv.ParseFloat(fields[3], 64)
+		if err != nil {
+			return nil, fmt.Errorf("Line %d: invalid duration '%s'.", lineNumber, fields[3])
+		}
+		events = append(events, DsSimulatorEvent{AllianceStation: strings.ToUpper(fields[0]), Type: fields[1],
+			StartSec: startSec, DurationSec: durationSec})
+	}
+	return events, scanner.Err()
+}
@@ -0,0 +1,137 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDsSimulatorEvents(t *testing.T) {
+	sim := DsSimulator{}
+	err := sim.AddEvent(DsSimulatorEvent{"R4", lowBatterySimEvent, 10, 5})
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "Invalid alliance station")
+	}
+	err = sim.AddEvent(DsSimulatorEvent{"R1", "blorpy", 10, 5})
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "Invalid simulated event type")
+	}
+	err = sim.AddEvent(DsSimulatorEvent{"R1", lowBatterySimEvent, 10, 0})
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "positive duration")
+	}
+
+	assert.Nil(t, sim.AddEvent(DsSimulatorEvent{"R1", lowBatterySimEvent, 10, 5}))
+	assert.Nil(t, sim.AddEvent(DsSimulatorEvent{"B3", dsLinkDropSimEvent, 0, 2}))
+	assert.False(t, sim.isEventActive("R1", lowBatterySimEvent, 9.9))
+	assert.True(t, sim.isEventActive("R1", lowBatterySimEvent, 10))
+	assert.True(t, sim.isEventActive("R1", lowBatterySimEvent, 14.9))
+	assert.False(t, sim.isEventActive("R1", lowBatterySimEvent, 15))
+	assert.False(t, sim.isEventActive("R2", lowBatterySimEvent, 12))
+	assert.False(t, sim.isEventActive("R1", tripTimeSpikeSimEvent, 12))
+	assert.True(t, sim.isEventActive("B3", dsLinkDropSimEvent, 1))
+
+	// Faults shouldn't be in effect outside of a match.
+	assert.False(t, sim.isEventActive("B3", dsLinkDropSimEvent, 0))
+
+	_, events := sim.Status()
+	assert.Equal(t, 2, len(events))
+	sim.ClearEvents()
+	_, events = sim.Status()
+	assert.Equal(t, 0, len(events))
+}
+
+func TestParseDsSimulatorScript(t *testing.T) {
+	script := "# Station, fault, start and duration\n\nr1 low_battery 10 5\nB2 trip_time_spike 30.5 2.5\n"
+	events, err := ParseDsSimulatorScript(strings.NewReader(script))
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(events)) {
+		assert.Equal(t, DsSimulatorEvent{"R1", lowBatterySimEvent, 10, 5}, events[0])
+		assert.Equal(t, DsSimulatorEvent{"B2", tripTimeSpikeSimEvent, 30.5, 2.5}, events[1])
+	}
+
+	_, err = ParseDsSimulatorScript(strings.NewReader("R1 low_battery 10\n"))
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "Line 1: expected")
+	}
+	_, err = ParseDsSimulatorScript(strings.NewReader("# Comment\nR1 low_battery 10 blorpy\n"))
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "Line 2: invalid duration")
+	}
+}
+
+func TestEncodeSimulatedPackets(t *testing.T) {
+	station := SimulatedDriverStation{TeamId: 1503, packetCount: 300}
+	packet := station.encodeUdpStatusPacket(true, 12.5, 3)
+	assert.Equal(t, []byte{1, 44, 0, 0x20, 5, 223, 12, 128}, packet[0:udpStatusHeaderBytes])
+	dsConn := DriverStationConnection{}
+	dsConn.decodeUdpTags(packet[udpStatusHeaderBytes:])
+	assert.Equal(t, 300, dsConn.SentPacketCount)
+	assert.Equal(t, 100, dsConn.LaptopBatteryPercent)
+	packet = station.encodeUdpStatusPacket(false, 0, 0)
+	assert.Equal(t, []byte{0, 0, 0, 0}, []byte{packet[3], packet[6], packet[7], packet[14]})
+
+	var statusPacket [36]byte
+	copy(statusPacket[:], encodeSimulatedTcpStatusPacket(6.5, 60)[2:])
+	dsConn.decodeStatusPacket(statusPacket)
+	assert.Equal(t, 60, dsConn.DsRobotTripTimeMs)
+	assert.Equal(t, 0, dsConn.MissedPacketCount)
+}
+
+func TestDsSimulator(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+
+	config := DsNetworkConfig{"127.0.0.1", "", 1750, 1160, 1121}
+	dsListeners.Restart(config)
+	mainArena.Setup()
+	mainArena.AssignTeam(254, "R1")
+	mainArena.AssignTeam(1114, "B3")
+	time.Sleep(time.Millisecond * 10)
+
+	assert.Nil(t, dsSimulator.Start(config))
+	defer dsSimulator.Stop()
+	assert.NotNil(t, dsSimulator.Start(config))
+	for i := 0; i < 100 && !(isDsConnected("R1") && isDsConnected("B3")); i++ {
+		time.Sleep(time.Millisecond * 10)
+	}
+	for _, allianceStation := range []string{"R1", "B3"} {
+		dsConn := mainArena.AllianceStations[allianceStation].DsConn
+		if assert.NotNil(t, dsConn) {
+			assert.Equal(t, mainArena.AllianceStations[allianceStation].Team.Id, dsConn.TeamId)
+			assert.True(t, dsConn.DsLinked)
+			assert.True(t, dsConn.RobotLinked)
+			assert.Equal(t, 12.5, dsConn.BatteryVoltage)
+		}
+	}
+	stations, _ := dsSimulator.Status()
+	if assert.Equal(t, 2, len(stations)) {
+		assert.Equal(t, "R1", stations[0].AllianceStation)
+		assert.Equal(t, 254, stations[0].TeamId)
+		assert.True(t, stations[0].Connected)
+		assert.Equal(t, "B3", stations[1].AllianceStation)
+	}
+
+	// Check that a team removed from the match is no longer simulated.
+	mainArena.AssignTeam(0, "B3")
+	time.Sleep(time.Millisecond * 50)
+	stations, _ = dsSimulator.Status()
+	assert.Equal(t, 1, len(stations))
+
+	dsSimulator.Stop()
+	assert.False(t, dsSimulator.Running)
+}
+
+func isDsConnected(allianceStation string) bool {
+	dsConn := mainArena.AllianceStations[allianceStation].DsConn
+	return dsConn != nil && dsConn.RobotLinked
+}
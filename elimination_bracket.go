@@ -0,0 +1,221 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Interface and implementations for the supported playoff bracket formats.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const defaultElimType = "single"
+
+// Encapsulates the structure of a playoff format: which matches are played between which alliances, and how those
+// matches are named and published.
+type EliminationBracket interface {
+	// Returns the human-readable name of the format.
+	Name() string
+
+	// Returns an error if the format cannot be run with the given number of alliances.
+	ValidateNumAlliances(numAlliances int) error
+
+	// Returns the display name of the given match in the bracket.
+	MatchDisplayName(round int, group int, instance int) string
+
+	// Returns the display name of the series of matches at the given point in the bracket.
+	SeriesName(round int, group int) string
+
+	// Returns the number of match wins an alliance needs to take the series at the given point in the bracket.
+	NumWinsToAdvance(round int) int
+
+	// Returns the competition level code that The Blue Alliance uses for matches in the given round.
+	TbaCompLevel(round int) string
+
+	// Creates any matches that can be created, based on the results of alliance selection or prior matches, and
+	// returns the winning alliance once it has been determined.
+	buildMatches(database *Database, numAlliances int) ([]AllianceTeam, error)
+}
+
+// All supported playoff formats, keyed by the identifier stored in the event settings.
+var eliminationBrackets = map[string]EliminationBracket{"single": new(SingleEliminationBracket),
+	"double": new(DoubleEliminationBracket), "roundrobin": new(RoundRobinBracket)}
+
+// Returns the playoff format selected in the event settings, falling back to the default if none is configured.
+func currentEliminationBracket() EliminationBracket {
+	if eventSettings != nil {
+		if bracket, ok := eliminationBrackets[eventSettings.ElimType]; ok {
+			return bracket
+		}
+	}
+	return eliminationBrackets[defaultElimType]
+}
+
+// Single-elimination bracket of best-of-three rounds for up to 16 alliances, with byes for the top seeds if the
+// number of alliances is not a power of two.
+type SingleEliminationBracket struct{}
+
+func (bracket *SingleEliminationBracket) Name() string {
+	return "Single Elimination"
+}
+
+func (bracket *SingleEliminationBracket) ValidateNumAlliances(numAlliances int) error {
+	if numAlliances < 2 || numAlliances > 16 {
+		return fmt.Errorf("Number of alliances must be between 2 and 16.")
+	}
+	return nil
+}
+
+func (bracket *SingleEliminationBracket) MatchDisplayName(round int, group int, instance int) string {
+	if round == 1 {
+		return fmt.Sprintf("F-%d", instance)
+	}
+	return fmt.Sprintf("%s%d-%d", elimRoundNames[round], group, instance)
+}
+
+func (bracket *SingleEliminationBracket) SeriesName(round int, group int) string {
+	if round == 1 {
+		return "F"
+	}
+	return fmt.Sprintf("%s%d", elimRoundNames[round], group)
+}
+
+func (bracket *SingleEliminationBracket) NumWinsToAdvance(round int) int {
+	return 2
+}
+
+func (bracket *SingleEliminationBracket) TbaCompLevel(round int) string {
+	return strings.ToLower(elimRoundNames[round])
+}
+
+func (bracket *SingleEliminationBracket) buildMatches(database *Database, numAlliances int) ([]AllianceTeam, error) {
+	return database.buildEliminationMatchSet(bracket, 1, 1, numAlliances)
+}
+
+// Eight-alliance double-elimination bracket as used by FIRST from 2023 onwards. Each bracket match is a single
+// match (replayed in the event of a tie) and the finals are best-of-three.
+type DoubleEliminationBracket struct{}
+
+// Identifies where an alliance in a double-elimination matchup comes from: either directly from alliance selection,
+// or from the winner or loser of an earlier bracket match.
+type doubleEliminationSource struct {
+	allianceId int
+	group      int
+	isLoser    bool
+}
+
+// A match in the double-elimination bracket. The round counts down towards the finals (which are round 1) so that
+// the matches sort in the order they are played, and the group is the bracket match number.
+type doubleEliminationMatchup struct {
+	round int
+	group int
+	red   doubleEliminationSource
+	blue  doubleEliminationSource
+}
+
+var doubleEliminationMatchups = []doubleEliminationMatchup{
+	{6, 1, doubleEliminationSource{allianceId: 1}, doubleEliminationSource{allianceId: 8}},
+	{6, 2, doubleEliminationSource{allianceId: 4}, doubleEliminationSource{allianceId: 5}},
+	{6, 3, doubleEliminationSource{allianceId: 2}, doubleEliminationSource{allianceId: 7}},
+	{6, 4, doubleEliminationSource{allianceId: 3}, doubleEliminationSource{allianceId: 6}},
+	{5, 5, doubleEliminationSource{group: 1, isLoser: true}, doubleEliminationSource{group: 2, isLoser: true}},
+	{5, 6, doubleEliminationSource{group: 3, isLoser: true}, doubleEliminationSource{group: 4, isLoser: true}},
+	{5, 7, doubleEliminationSource{group: 1}, doubleEliminationSource{group: 2}},
+	{5, 8, doubleEliminationSource{group: 3}, doubleEliminationSource{group: 4}},
+	{4, 9, doubleEliminationSource{group: 7, isLoser: true}, doubleEliminationSource{group: 6}},
+	{4, 10, doubleEliminationSource{group: 8, isLoser: true}, doubleEliminationSource{group: 5}},
+	{3, 11, doubleEliminationSource{group: 7}, doubleEliminationSource{group: 8}},
+	{3, 12, doubleEliminationSource{group: 10}, doubleEliminationSource{group: 9}},
+	{2, 13, doubleEliminationSource{group: 11, isLoser: true}, doubleEliminationSource{group: 12}},
+	{1, 1, doubleEliminationSource{group: 11}, doubleEliminationSource{group: 13}},
+}
+
+func (bracket *DoubleEliminationBracket) Name() string {
+	return "Double Elimination"
+}
+
+func (bracket *DoubleEliminationBracket) ValidateNumAlliances(numAlliances int) error {
+	if numAlliances != 8 {
+		return fmt.Errorf("Number of alliances must be 8 for a double-elimination bracket.")
+	}
+	return nil
+}
+
+func (bracket *DoubleEliminationBracket) MatchDisplayName(round int, group int, instance int) string {
+	if round == 1 {
+		return fmt.Sprintf("F-%d", instance)
+	}
+	if instance == 1 {
+		return fmt.Sprintf("M%d", group)
+	}
+	return fmt.Sprintf("M%d-%d", group, instance)
+}
+
+func (bracket *DoubleEliminationBracket) SeriesName(round int, group int) string {
+	if round == 1 {
+		return "F"
+	}
+	return fmt.Sprintf("M%d", group)
+}
+
+func (bracket *DoubleEliminationBracket) NumWinsToAdvance(round int) int {
+	if round == 1 {
+		return 2
+	}
+	return 1
+}
+
+func (bracket *DoubleEliminationBracket) TbaCompLevel(round int) string {
+	if round == 1 {
+		return "f"
+	}
+	return "sf"
+}
+
+func (bracket *DoubleEliminationBracket) buildMatches(database *Database, numAlliances int) ([]AllianceTeam, error) {
+	if err := bracket.ValidateNumAlliances(numAlliances); err != nil {
+		return []AllianceTeam{}, err
+	}
+
+	// The matchups are listed in an order such that every source is resolved before it is needed.
+	winners := make(map[int][]AllianceTeam)
+	losers := make(map[int][]AllianceTeam)
+	for _, matchup := range doubleEliminationMatchups {
+		redAlliance, err := matchup.red.getAlliance(database, winners, losers)
+		if err != nil {
+			return []AllianceTeam{}, err
+		}
+		blueAlliance, err := matchup.blue.getAlliance(database, winners, losers)
+		if err != nil {
+			return []AllianceTeam{}, err
+		}
+		if len(redAlliance) == 0 && len(blueAlliance) == 0 {
+			continue
+		}
+
+		winner, loser, err := database.updateEliminationMatchSet(bracket, matchup.round, matchup.group,
+			bracket.NumWinsToAdvance(matchup.round), redAlliance, blueAlliance)
+		if err != nil {
+			return []AllianceTeam{}, err
+		}
+		if matchup.round == 1 {
+			return winner, nil
+		}
+		winners[matchup.group] = winner
+		losers[matchup.group] = loser
+	}
+	return []AllianceTeam{}, nil
+}
+
+// Returns the alliance that the source refers to, or an empty slice if it is not yet known.
+func (source *doubleEliminationSource) getAlliance(database *Database, winners map[int][]AllianceTeam,
+	losers map[int][]AllianceTeam) ([]AllianceTeam, error) {
+	if source.allianceId > 0 {
+		return database.GetTeamsByAlliance(source.allianceId)
+	}
+	if source.isLoser {
+		return losers[source.group], nil
+	}
+	return winners[source.group], nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCurrentEliminationBracket(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+
+	assert.Equal(t, eliminationBrackets["single"], currentEliminationBracket())
+	eventSettings.ElimType = "double"
+	assert.Equal(t, eliminationBrackets["double"], currentEliminationBracket())
+
+	// Fall back to the default format if the configured one is unknown.
+	eventSettings.ElimType = "blorpy"
+	assert.Equal(t, eliminationBrackets[defaultElimType], currentEliminationBracket())
+	eventSettings.ElimType = ""
+	assert.Equal(t, eliminationBrackets[defaultElimType], currentEliminationBracket())
+}
+
+func TestDoubleEliminationSchedule(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+	eventSettings.ElimType = "double"
+	defer func() { eventSettings.ElimType = defaultElimType }()
+
+	createTestAlliances(db, 4)
+	_, err = db.UpdateEliminationSchedule(time.Unix(0, 0))
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "must be 8 for a double-elimination bracket")
+	}
+	db.TruncateAllianceTeams()
+
+	createTestAlliances(db, 8)
+	_, err = db.UpdateEliminationSchedule(time.Unix(0, 0))
+	assert.Nil(t, err)
+	matches, _ := db.GetMatchesByType("elimination")
+	if assert.Equal(t, 4, len(matches)) {
+		assertMatch(t, matches[0], "M1", 1, 8)
+		assertMatch(t, matches[1], "M2", 4, 5)
+		assertMatch(t, matches[2], "M3", 2, 7)
+		assertMatch(t, matches[3], "M4", 3, 6)
+	}
+
+	// Winners and losers of the first round should split into the upper and lower brackets.
+	scoreMatch(db, "M1", "R")
+	scoreMatch(db, "M2", "B")
+	scoreMatch(db, "M3", "R")
+	scoreMatch(db, "M4", "R")
+	_, err = db.UpdateEliminationSchedule(time.Unix(0, 0))
+	assert.Nil(t, err)
+	matches, _ = db.GetMatchesByType("elimination")
+	if assert.Equal(t, 8, len(matches)) {
+		assertMatch(t, matches[4], "M5", 8, 4)
+		assertMatch(t, matches[5], "M6", 7, 6)
+		assertMatch(t, matches[6], "M7", 1, 5)
+		assertMatch(t, matches[7], "M8", 2, 3)
+	}
+
+	// A tie should be replayed, and matches should be created once one of their alliances is known.
+	scoreMatch(db, "M5", "R")
+	scoreMatch(db, "M6", "T")
+	scoreMatch(db, "M7", "R")
+	scoreMatch(db, "M8", "B")
+	_, err = db.UpdateEliminationSchedule(time.Unix(0, 0))
+	assert.Nil(t, err)
+	matches, _ = db.GetMatchesByType("elimination")
+	if assert.Equal(t, 12, len(matches)) {
+		assertMatch(t, matches[8], "M6-2", 7, 6)
+		assertMatch(t, matches[9], "M9", 5, 0)
+		assertMatch(t, matches[10], "M10", 2, 8)
+		assertMatch(t, matches[11], "M11", 1, 3)
+	}
+
+	scoreMatch(db, "M6-2", "B")
+	_, err = db.UpdateEliminationSchedule(time.Unix(0, 0))
+	assert.Nil(t, err)
+	scoreMatch(db, "M9", "R")
+	scoreMatch(db, "M10", "B")
+	scoreMatch(db, "M11", "R")
+	_, err = db.UpdateEliminationSchedule(time.Unix(0, 0))
+	assert.Nil(t, err)
+	matches, _ = db.GetMatchesByType("elimination")
+	if assert.Equal(t, 17, len(matches)) {
+		assertMatch(t, matches[9], "M9", 5, 6)
+		assertMatch(t, matches[12], "M12", 8, 5)
+		assertMatch(t, matches[13], "M13", 3, 0)
+		assertMatch(t, matches[14], "F-1", 1, 0)
+		assertMatch(t, matches[15], "F-2", 1, 0)
+		assertMatch(t, matches[16], "F-3", 1, 0)
+	}
+
+	scoreMatch(db, "M12", "B")
+	_, err = db.UpdateEliminationSchedule(time.Unix(0, 0))
+	assert.Nil(t, err)
+	scoreMatch(db, "M13", "B")
+	_, err = db.UpdateEliminationSchedule(time.Unix(0, 0))
+	assert.Nil(t, err)
+	matches, _ = db.GetMatchesByType("elimination")
+	if assert.Equal(t, 17, len(matches)) {
+		assertMatch(t, matches[13], "M13", 3, 5)
+		assertMatch(t, matches[14], "F-1", 1, 5)
+		assertMatch(t, matches[16], "F-3", 1, 5)
+	}
+
+	// The finals are best-of-three.
+	scoreMatch(db, "F-1", "R")
+	winner, err := db.UpdateEliminationSchedule(time.Unix(0, 0))
+	assert.Nil(t, err)
+	assert.Empty(t, winner)
+	scoreMatch(db, "F-2", "R")
+	winner, err = db.UpdateEliminationSchedule(time.Unix(0, 0))
+	assert.Nil(t, err)
+	if assert.Equal(t, 3, len(winner)) {
+		assert.Equal(t, 1, winner[0].TeamId)
+	}
+	matches, _ = db.GetMatchesByType("elimination")
+	assert.Equal(t, 16, len(matches))
+
+	match, _ := db.GetMatchByName("elimination", "M13")
+	assert.Equal(t, "sf13m1", match.TbaCode())
+	match, _ = db.GetMatchByName("elimination", "M6-2")
+	assert.Equal(t, "sf6m2", match.TbaCode())
+	match, _ = db.GetMatchByName("elimination", "F-2")
+	assert.Equal(t, "f1m2", match.TbaCode())
+}
@@ -7,7 +7,6 @@ package main
 
 import (
 	"fmt"
-	"strconv"
 	"time"
 )
 
@@ -22,7 +21,7 @@ func (database *Database) UpdateEliminationSchedule(startTime time.Time) ([]Alli
 	if err != nil {
 		return []AllianceTeam{}, err
 	}
-	winner, err := database.buildEliminationMatchSet(1, 1, len(alliances))
+	winner, err := currentEliminationBracket().buildMatches(database, len(alliances))
 	if err != nil {
 		return []AllianceTeam{}, err
 	}
@@ -45,19 +44,69 @@ func (database *Database) UpdateEliminationSchedule(startTime time.Time) ([]Alli
 	return winner, err
 }
 
-// Recursively traverses the elimination bracket downwards, creating matches as necessary. Returns the winner
-// of the given round if known.
-func (database *Database) buildEliminationMatchSet(round int, group int, numAlliances int) ([]AllianceTeam, error) {
+// Returns the matches in later rounds of the bracket whose alliances were determined by the result of the given
+// match, either because one of its alliances advanced into them or, in a round robin, because the finals were seeded
+// from the standings. Also returns any later matches in the same series that have already been played, since
+// whether they were needed at all depended on the given match.
+func (database *Database) GetDependentEliminationMatches(match *Match) ([]Match, error) {
+	alliances, err := database.GetAllAlliances()
+	if err != nil {
+		return nil, err
+	}
+	teamAlliances := make(map[int]int)
+	for _, alliance := range alliances {
+		for _, allianceTeam := range alliance {
+			teamAlliances[allianceTeam.TeamId] = allianceTeam.AllianceId
+		}
+	}
+	redAllianceId := findAllianceId(teamAlliances, match.Red1, match.Red2, match.Red3)
+	blueAllianceId := findAllianceId(teamAlliances, match.Blue1, match.Blue2, match.Blue3)
+	_, isRoundRobin := currentEliminationBracket().(*RoundRobinBracket)
+
+	matches, err := database.GetMatchesByType("elimination")
+	if err != nil {
+		return nil, err
+	}
+	var dependentMatches []Match
+	for _, laterMatch := range matches {
+		if laterMatch.ElimRound == match.ElimRound && laterMatch.ElimGroup == match.ElimGroup &&
+			laterMatch.ElimInstance > match.ElimInstance {
+			// Unplayed matches in the same series are left for the bracket update to keep or delete.
+			if laterMatch.Status == "complete" {
+				dependentMatches = append(dependentMatches, laterMatch)
+			}
+			continue
+		}
+
+		// Rounds count down towards the finals in every format.
+		if laterMatch.ElimRound >= match.ElimRound {
+			continue
+		}
+		if isRoundRobin && match.ElimRound == roundRobinRound {
+			dependentMatches = append(dependentMatches, laterMatch)
+			continue
+		}
+		for _, allianceId := range []int{findAllianceId(teamAlliances, laterMatch.Red1, laterMatch.Red2,
+			laterMatch.Red3), findAllianceId(teamAlliances, laterMatch.Blue1, laterMatch.Blue2, laterMatch.Blue3)} {
+			if allianceId > 0 && (allianceId == redAllianceId || allianceId == blueAllianceId) {
+				dependentMatches = append(dependentMatches, laterMatch)
+				break
+			}
+		}
+	}
+	return dependentMatches, nil
+}
+
+// Recursively traverses the single-elimination bracket downwards, creating matches as necessary. Returns the
+// winner of the given round if known.
+func (database *Database) buildEliminationMatchSet(bracket EliminationBracket, round int, group int,
+	numAlliances int) ([]AllianceTeam, error) {
 	if numAlliances < 2 {
 		return []AllianceTeam{}, fmt.Errorf("Must have at least 2 alliances")
 	}
-	roundName, ok := elimRoundNames[round]
-	if !ok {
+	if _, ok := elimRoundNames[round]; !ok {
 		return []AllianceTeam{}, fmt.Errorf("Round of depth %d is not supported", round*2)
 	}
-	if round != 1 {
-		roundName += strconv.Itoa(group)
-	}
 
 	// Recurse to figure out who the involved alliances are.
 	var redAlliance, blueAlliance []AllianceTeam
@@ -88,13 +137,13 @@ func (database *Database) buildEliminationMatchSet(round int, group int, numAlli
 
 	// If the alliances aren't known yet, get them from one round down in the bracket.
 	if len(redAlliance) == 0 {
-		redAlliance, err = database.buildEliminationMatchSet(round*2, group*2-1, numAlliances)
+		redAlliance, err = database.buildEliminationMatchSet(bracket, round*2, group*2-1, numAlliances)
 		if err != nil {
 			return []AllianceTeam{}, err
 		}
 	}
 	if len(blueAlliance) == 0 {
-		blueAlliance, err = database.buildEliminationMatchSet(round*2, group*2, numAlliances)
+		blueAlliance, err = database.buildEliminationMatchSet(bracket, round*2, group*2, numAlliances)
 		if err != nil {
 			return []AllianceTeam{}, err
 		}
@@ -105,12 +154,23 @@ func (database *Database) buildEliminationMatchSet(round int, group int, numAlli
 		return []AllianceTeam{}, nil
 	}
 
+	winner, _, err := database.updateEliminationMatchSet(bracket, round, group, bracket.NumWinsToAdvance(round),
+		redAlliance, blueAlliance)
+	return winner, err
+}
+
+// Creates or updates the matches between the two given alliances at the given point in the bracket, where either
+// alliance may not be known yet. Returns the winning and losing alliances once the given number of wins has been
+// reached by one of them.
+func (database *Database) updateEliminationMatchSet(bracket EliminationBracket, round int, group int,
+	numWinsToAdvance int, redAlliance []AllianceTeam, blueAlliance []AllianceTeam) ([]AllianceTeam, []AllianceTeam,
+	error) {
 	// Check if the match set exists already and if it has been won.
 	var redWins, blueWins, numIncomplete int
 	var ties []*Match
 	matches, err := database.GetMatchesByElimRoundGroup(round, group)
 	if err != nil {
-		return []AllianceTeam{}, err
+		return []AllianceTeam{}, []AllianceTeam{}, err
 	}
 	var unplayedMatches []*Match
 	for _, match := range matches {
@@ -140,24 +200,25 @@ func (database *Database) buildEliminationMatchSet(round int, group int, numAlli
 		case "T":
 			ties = append(ties, &match)
 		default:
-			return []AllianceTeam{}, fmt.Errorf("Completed match %d has invalid winner '%s'", match.Id, match.Winner)
+			return []AllianceTeam{}, []AllianceTeam{}, fmt.Errorf("Completed match %d has invalid winner '%s'",
+				match.Id, match.Winner)
 		}
 	}
 
 	// Delete any superfluous matches if the round is won.
-	if redWins == 2 || blueWins == 2 {
+	if redWins == numWinsToAdvance || blueWins == numWinsToAdvance {
 		for _, match := range unplayedMatches {
 			err = database.DeleteMatch(match)
 			if err != nil {
-				return []AllianceTeam{}, err
+				return []AllianceTeam{}, []AllianceTeam{}, err
 			}
 		}
 
 		// Bail out and announce the winner of this round.
-		if redWins == 2 {
-			return redAlliance, nil
+		if redWins == numWinsToAdvance {
+			return redAlliance, blueAlliance, nil
 		} else {
-			return blueAlliance, nil
+			return blueAlliance, redAlliance, nil
 		}
 	}
 
@@ -172,24 +233,12 @@ func (database *Database) buildEliminationMatchSet(round int, group int, numAlli
 		}
 		if len(redAlliance) < 3 || len(blueAlliance) < 3 {
 			// Raise an error if the alliance selection process gave us less than 3 teams per alliance.
-			return []AllianceTeam{}, fmt.Errorf("Alliances must consist of at least 3 teams")
+			return []AllianceTeam{}, []AllianceTeam{}, fmt.Errorf("Alliances must consist of at least 3 teams")
 		}
-		if len(matches) < 1 {
-			err = database.CreateMatch(createMatch(roundName, round, group, 1, redAlliance, blueAlliance))
+		for instance := len(matches) + 1; instance <= 2*numWinsToAdvance-1; instance++ {
+			err = database.CreateMatch(createMatch(bracket, round, group, instance, redAlliance, blueAlliance))
 			if err != nil {
-				return []AllianceTeam{}, err
-			}
-		}
-		if len(matches) < 2 {
-			err = database.CreateMatch(createMatch(roundName, round, group, 2, redAlliance, blueAlliance))
-			if err != nil {
-				return []AllianceTeam{}, err
-			}
-		}
-		if len(matches) < 3 {
-			err = database.CreateMatch(createMatch(roundName, round, group, 3, redAlliance, blueAlliance))
-			if err != nil {
-				return []AllianceTeam{}, err
+				return []AllianceTeam{}, []AllianceTeam{}, err
 			}
 		}
 	}
@@ -198,22 +247,23 @@ func (database *Database) buildEliminationMatchSet(round int, group int, numAlli
 	// personnel can reuse any tied matches without having to print new schedules.
 	if numIncomplete == 0 {
 		for index, tie := range ties {
-			match := createMatch(roundName, round, group, len(matches)+index+1, redAlliance, blueAlliance)
+			match := createMatch(bracket, round, group, len(matches)+index+1, redAlliance, blueAlliance)
 			match.Red1, match.Red2, match.Red3 = tie.Red1, tie.Red2, tie.Red3
 			match.Blue1, match.Blue2, match.Blue3 = tie.Blue1, tie.Blue2, tie.Blue3
 			err = database.CreateMatch(match)
 			if err != nil {
-				return []AllianceTeam{}, err
+				return []AllianceTeam{}, []AllianceTeam{}, err
 			}
 		}
 	}
 
-	return []AllianceTeam{}, nil
+	return []AllianceTeam{}, []AllianceTeam{}, nil
 }
 
 // Creates a match at the given point in the elimination bracket and populates the teams.
-func createMatch(roundName string, round int, group int, instance int, redAlliance []AllianceTeam, blueAlliance []AllianceTeam) *Match {
-	match := Match{Type: "elimination", DisplayName: fmt.Sprintf("%s-%d", roundName, instance),
+func createMatch(bracket EliminationBracket, round int, group int, instance int, redAlliance []AllianceTeam,
+	blueAlliance []AllianceTeam) *Match {
+	match := Match{Type: "elimination", DisplayName: bracket.MatchDisplayName(round, group, instance),
 		ElimRound: round, ElimGroup: group, ElimInstance: instance}
 	positionRedTeams(&match, redAlliance)
 	positionBlueTeams(&match, blueAlliance)
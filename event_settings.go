@@ -11,6 +11,8 @@ type EventSettings struct {
 	Code                       string
 	DisplayBackgroundColor     string
 	NumElimAlliances           int
+	ElimType                   string
+	RoundRobinPointsRule       string
 	SelectionRound2Order       string
 	SelectionRound3Order       string
 	TBADownloadEnabled         bool
@@ -25,6 +27,12 @@ type EventSettings struct {
 	SwitchAddress              string
 	SwitchPassword             string
 	BandwidthMonitoringEnabled bool
+	DsProtocol                 string
+	DsListenAddress            string
+	DsListenInterface          string
+	DsTcpListenPort            int
+	DsUdpReceivePort           int
+	DsUdpSendPort              int
 	AdminPassword              string
 	ReaderPassword             string
 	RedDefenseLightsAddress    string
@@ -32,6 +40,13 @@ type EventSettings struct {
 	InitialTowerStrength       int
 	StemTvPublishingEnabled    bool
 	StemTvEventCode            string
+	AutoDurationSec            int
+	PauseDurationSec           int
+	TeleopDurationSec          int
+	EndgameTimeLeftSec         int
+	FieldResetWarningSec       int
+	FieldResetLimitSec         int
+	Game                       string
 }
 
 const eventSettingsId = 0
@@ -45,11 +60,25 @@ func (database *Database) GetEventSettings() (*EventSettings, error) {
 		eventSettings.Code = "UE"
 		eventSettings.DisplayBackgroundColor = "#00ff00"
 		eventSettings.NumElimAlliances = 8
+		eventSettings.ElimType = defaultElimType
+		eventSettings.RoundRobinPointsRule = defaultRoundRobinPointsRule
 		eventSettings.SelectionRound2Order = "L"
 		eventSettings.SelectionRound3Order = ""
 		eventSettings.TBADownloadEnabled = true
+		eventSettings.AutoDurationSec = 15
+		eventSettings.PauseDurationSec = 2
+		eventSettings.TeleopDurationSec = 135
+		eventSettings.EndgameTimeLeftSec = 30
+		eventSettings.FieldResetWarningSec = 120
+		eventSettings.FieldResetLimitSec = 180
+		eventSettings.DsProtocol = defaultDsProtocol
+		eventSettings.DsListenAddress = defaultDsListenAddress
+		eventSettings.DsTcpListenPort = driverStationTcpListenPort
+		eventSettings.DsUdpReceivePort = driverStationUdpReceivePort
+		eventSettings.DsUdpSendPort = driverStationUdpSendPort
 
 		// Game-specific default settings.
+		eventSettings.Game = defaultGame
 		eventSettings.InitialTowerStrength = 10
 
 		err = database.eventSettingsMap.Insert(eventSettings)
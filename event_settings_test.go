@@ -18,8 +18,11 @@ func TestEventSettingsReadWrite(t *testing.T) {
 	eventSettings, err := db.GetEventSettings()
 	assert.Nil(t, err)
 	assert.Equal(t, EventSettings{Id: 0, Name: "Untitled Event", Code: "UE", DisplayBackgroundColor: "#00ff00",
-		NumElimAlliances: 8, SelectionRound2Order: "L", SelectionRound3Order: "", TBADownloadEnabled: true,
-		InitialTowerStrength: 10}, *eventSettings)
+		NumElimAlliances: 8, ElimType: "single", RoundRobinPointsRule: "wlt", SelectionRound2Order: "L",
+		SelectionRound3Order: "", TBADownloadEnabled: true, AutoDurationSec: 15, PauseDurationSec: 2,
+		TeleopDurationSec: 135, EndgameTimeLeftSec: 30, FieldResetWarningSec: 120, FieldResetLimitSec: 180,
+		DsProtocol: "2015", DsListenAddress: "10.0.100.5", DsTcpListenPort: 1750, DsUdpReceivePort: 1160,
+		DsUdpSendPort: 1121, Game: "stronghold", InitialTowerStrength: 10}, *eventSettings)
 
 	eventSettings.Name = "Chezy Champs"
 	eventSettings.Code = "cc"
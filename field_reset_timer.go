@@ -0,0 +1,57 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Arena logic for timing how long the field has been in reset after a match.
+
+package main
+
+import (
+	"time"
+)
+
+type FieldResetTimerStatus struct {
+	Running    bool
+	ElapsedSec int
+	Status     string
+}
+
+// Returns how long the field reset timer has been running and whether its thresholds have been reached.
+func (arena *Arena) FieldResetTimerStatus() FieldResetTimerStatus {
+	if arena.fieldResetStartTime.IsZero() {
+		return FieldResetTimerStatus{}
+	}
+	elapsedSec := int(time.Since(arena.fieldResetStartTime).Seconds())
+	status := "ok"
+	if elapsedSec >= eventSettings.FieldResetLimitSec {
+		status = "expired"
+	} else if elapsedSec >= eventSettings.FieldResetWarningSec {
+		status = "warning"
+	}
+	return FieldResetTimerStatus{true, elapsedSec, status}
+}
+
+// Starts the field reset timer from zero, or restarts it if it is already running.
+func (arena *Arena) startFieldResetTimer() {
+	arena.fieldResetStartTime = time.Now()
+	arena.lastFieldResetTimerSec = 0
+	arena.fieldResetTimerNotifier.Notify(nil)
+}
+
+func (arena *Arena) stopFieldResetTimer() {
+	if !arena.fieldResetStartTime.IsZero() {
+		arena.fieldResetStartTime = time.Time{}
+		arena.fieldResetTimerNotifier.Notify(nil)
+	}
+}
+
+// Sends a notification whenever the field reset timer passes an integer second threshold.
+func (arena *Arena) updateFieldResetTimer() {
+	if arena.fieldResetStartTime.IsZero() {
+		return
+	}
+	elapsedSec := arena.FieldResetTimerStatus().ElapsedSec
+	if elapsedSec != arena.lastFieldResetTimerSec {
+		arena.fieldResetTimerNotifier.Notify(nil)
+	}
+	arena.lastFieldResetTimerSec = elapsedSec
+}
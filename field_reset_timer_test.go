@@ -0,0 +1,59 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestFieldResetTimer(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+	mainArena.Setup()
+	assert.Equal(t, FieldResetTimerStatus{}, mainArena.FieldResetTimerStatus())
+
+	// The timer should start once the match ends.
+	mainArena.AllianceStations["R1"].Bypass = true
+	mainArena.AllianceStations["R2"].Bypass = true
+	mainArena.AllianceStations["R3"].Bypass = true
+	mainArena.AllianceStations["B1"].Bypass = true
+	mainArena.AllianceStations["B2"].Bypass = true
+	mainArena.AllianceStations["B3"].Bypass = true
+	assert.Nil(t, mainArena.StartMatch())
+	mainArena.Update()
+	assert.False(t, mainArena.FieldResetTimerStatus().Running)
+	assert.Nil(t, mainArena.AbortMatch())
+	mainArena.Update()
+	assert.Equal(t, FieldResetTimerStatus{true, 0, "ok"}, mainArena.FieldResetTimerStatus())
+
+	// Check that the status changes as the thresholds are reached.
+	mainArena.fieldResetStartTime = time.Now().Add(-time.Duration(eventSettings.FieldResetWarningSec) * time.Second)
+	assert.Equal(t, "warning", mainArena.FieldResetTimerStatus().Status)
+	mainArena.fieldResetStartTime = time.Now().Add(-time.Duration(eventSettings.FieldResetLimitSec) * time.Second)
+	assert.Equal(t, "expired", mainArena.FieldResetTimerStatus().Status)
+	assert.Equal(t, eventSettings.FieldResetLimitSec, mainArena.FieldResetTimerStatus().ElapsedSec)
+
+	// Signalling the field reset should restart the timer.
+	mainArena.startFieldResetTimer()
+	assert.Equal(t, FieldResetTimerStatus{true, 0, "ok"}, mainArena.FieldResetTimerStatus())
+
+	// The timer should stop once the next match starts.
+	mainArena.ResetMatch()
+	mainArena.AllianceStations["R1"].Bypass = true
+	mainArena.AllianceStations["R2"].Bypass = true
+	mainArena.AllianceStations["R3"].Bypass = true
+	mainArena.AllianceStations["B1"].Bypass = true
+	mainArena.AllianceStations["B2"].Bypass = true
+	mainArena.AllianceStations["B3"].Bypass = true
+	assert.Nil(t, mainArena.StartMatch())
+	mainArena.Update()
+	assert.Equal(t, FieldResetTimerStatus{}, mainArena.FieldResetTimerStatus())
+}
@@ -35,6 +35,17 @@ func FtaDisplayHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Show the logs from the most recently played match so that robot messages can be pulled up right away.
+	matchLogGroups, err := ListMatchLogs()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	var latestMatchLogs *MatchLogGroup
+	if len(matchLogGroups) > 0 {
+		latestMatchLogs = &matchLogGroups[0]
+	}
+
 	template := template.New("").Funcs(templateHelpers)
 	_, err = template.ParseFiles("templates/fta_display.html", "templates/base.html")
 	if err != nil {
@@ -43,9 +54,11 @@ func FtaDisplayHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	data := struct {
 		*EventSettings
-		UpcomingMatches []Match
-		DefenseNames    map[string]string
-	}{eventSettings, upcomingMatches, defenseNames}
+		UpcomingMatches  []Match
+		DefenseNames     map[string]string
+		AllianceStations []string
+		LatestMatchLogs  *MatchLogGroup
+	}{eventSettings, upcomingMatches, defenseNames, stationNames, latestMatchLogs}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
 		handleWebErr(w, err)
@@ -70,6 +83,10 @@ func FtaDisplayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 	defer close(defenseSelectionListener)
 	reloadDisplaysListener := mainArena.reloadDisplaysNotifier.Listen()
 	defer close(reloadDisplaysListener)
+	fieldResetTimerListener := mainArena.fieldResetTimerNotifier.Listen()
+	defer close(fieldResetTimerListener)
+	dsListenerStatusListener := dsListeners.Notifier.Listen()
+	defer close(dsListenerStatusListener)
 
 	// Send the various notifications immediately upon connection.
 	err = websocket.Write("status", mainArena)
@@ -77,6 +94,16 @@ func FtaDisplayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 		log.Printf("Websocket error: %s", err)
 		return
 	}
+	err = websocket.Write("fieldResetTimer", mainArena.FieldResetTimerStatus())
+	if err != nil {
+		log.Printf("Websocket error: %s", err)
+		return
+	}
+	err = websocket.Write("dsListenerStatus", dsListeners.Status())
+	if err != nil {
+		log.Printf("Websocket error: %s", err)
+		return
+	}
 
 	// Spin off a goroutine to listen for notifications and pass them on through the websocket.
 	go func() {
@@ -102,6 +129,18 @@ func FtaDisplayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 				}
 				messageType = "reload"
 				message = nil
+			case _, ok := <-fieldResetTimerListener:
+				if !ok {
+					return
+				}
+				messageType = "fieldResetTimer"
+				message = mainArena.FieldResetTimerStatus()
+			case dsListenerStatus, ok := <-dsListenerStatusListener:
+				if !ok {
+					return
+				}
+				messageType = "dsListenerStatus"
+				message = dsListenerStatus
 			}
 			err = websocket.Write(messageType, message)
 			if err != nil {
@@ -111,7 +150,8 @@ func FtaDisplayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}()
 
-	// Loop, waiting for commands and responding to them, until the client closes the connection.
+	// Loop, waiting for commands and responding to them, until the client closes the connection. Robot stops are
+	// sent through the authenticated API instead, since this websocket can't yet require authentication.
 	for {
 		_, _, err := websocket.Read()
 		if err != nil {
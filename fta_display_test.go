@@ -4,7 +4,9 @@
 package main
 
 import (
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
+	"sync"
 	"testing"
 )
 
@@ -20,4 +22,47 @@ func TestFtaDisplay(t *testing.T) {
 	recorder := getHttpResponse("/displays/fta")
 	assert.Equal(t, 200, recorder.Code)
 	assert.Contains(t, recorder.Body.String(), "Field Monitor - Untitled Event - Cheesy Arena")
+	assert.Contains(t, recorder.Body.String(), "diagnosticsB3")
+
+	// Check that the logs from the latest match are linked.
+	writeTestMatchLog(t)
+	defer removeTestMatchLog()
+	recorder = getHttpResponse("/displays/fta")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Logs from Qualification 12")
+	assert.Contains(t, recorder.Body.String(), "/static/logs/"+matchLogMessagesFilename(testMatchLogFilename))
+}
+
+func TestFtaDisplayWebsocket(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+	mainArena.Setup()
+
+	server, wsUrl := startTestServer()
+	defer server.Close()
+	conn, _, err := websocket.DefaultDialer.Dial(wsUrl+"/displays/fta/websocket", nil)
+	assert.Nil(t, err)
+	defer conn.Close()
+	ws := &Websocket{conn, new(sync.Mutex)}
+
+	// Should get a few status updates right after connection.
+	readWebsocketType(t, ws, "status")
+	readWebsocketType(t, ws, "fieldResetTimer")
+	readWebsocketType(t, ws, "dsListenerStatus")
+
+	// Check that rebinding the driver station listeners is reported.
+	dsListeners.Restart(DsNetworkConfig{"127.0.0.1", "", 17500, 11600, 11210})
+	defer dsListeners.Restart(DsNetworkConfig{"127.0.0.1", "", 1750, 1160, 1121})
+	status, ok := readWebsocketType(t, ws, "dsListenerStatus").(map[string]interface{})
+	if assert.True(t, ok) {
+		assert.Equal(t, "127.0.0.1", status["Address"])
+		assert.Equal(t, 17500.0, status["TcpPort"])
+		assert.Equal(t, true, status["TcpBound"])
+		assert.Equal(t, true, status["UdpBound"])
+	}
 }
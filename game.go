@@ -0,0 +1,72 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Interface for the game-specific scoring and ranking rules, so that the arena can be reused from season to season.
+
+package main
+
+const defaultGame = "stronghold"
+
+// The game-specific scoring data for one alliance in a match. Its concrete type is owned by the game, and it is held
+// by value so that copies of a score don't share state.
+type GameScore interface{}
+
+// The game-specific fields of a score summary, such as bonus objectives. Its concrete type is owned by the game.
+type GameScoreSummary interface{}
+
+// Describes one of the values used to break ties in the rankings.
+type Tiebreaker struct {
+	Name       string // Identifier used in exported data.
+	Label      string // Human-readable name, as published to The Blue Alliance.
+	ShortLabel string // Abbreviated name for narrow table columns.
+}
+
+// Encapsulates the rules of a particular season's game.
+type Game interface {
+	// Returns the human-readable name of the game.
+	Name() string
+
+	// Returns the game-specific scoring data for an alliance that has not yet scored anything.
+	NewScore() GameScore
+
+	// Extracts the game-specific scoring data from the given JSON-encoded alliance score.
+	DecodeScore(jsonData []byte) (GameScore, error)
+
+	// Calculates and returns the summary fields used for ranking and display for one alliance's score.
+	ScoreSummary(score *Score, opponentFouls []Foul, matchType string) *ScoreSummary
+
+	// Returns the number of ranking points (including any bonus ranking points) that an alliance earns for the
+	// given qualification match outcome.
+	RankingPoints(ownScore *ScoreSummary, opponentScore *ScoreSummary) int
+
+	// Returns the tiebreakers that are applied after ranking points, in order of precedence.
+	Tiebreakers() []Tiebreaker
+
+	// Returns the points that the given qualification match outcome contributes towards each tiebreaker, in the same
+	// order as Tiebreakers(). Tied teams are compared on the per-match average of each.
+	TiebreakerPoints(ownScore *ScoreSummary) []int
+}
+
+// All supported games, keyed by the identifier stored in the event settings.
+var games = map[string]Game{"stronghold": new(Stronghold)}
+
+// Returns the game selected in the event settings, falling back to the default if none is configured.
+func currentGame() Game {
+	if eventSettings != nil {
+		if game, ok := games[eventSettings.Game]; ok {
+			return game
+		}
+	}
+	return games[defaultGame]
+}
+
+// Compares the two per-match averages of a tiebreaker value, using cross-multiplication to keep it in integer
+// math. Returns 1 if a is greater, -1 if b is greater, and 0 if they are equal.
+func compareAverages(aValue int, aPlayed int, bValue int, bPlayed int) int {
+	if aValue*bPlayed > bValue*aPlayed {
+		return 1
+	} else if aValue*bPlayed < bValue*aPlayed {
+		return -1
+	}
+	return 0
+}
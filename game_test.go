@@ -0,0 +1,58 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCurrentGame(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+
+	assert.Equal(t, games["stronghold"], currentGame())
+
+	// Fall back to the default game if the configured one is unknown.
+	eventSettings.Game = "blorpy"
+	assert.Equal(t, games[defaultGame], currentGame())
+	eventSettings.Game = ""
+	assert.Equal(t, games[defaultGame], currentGame())
+}
+
+func TestStrongholdRankingPoints(t *testing.T) {
+	game := new(Stronghold)
+	assert.Equal(t, 2, game.RankingPoints(&ScoreSummary{Score: 10}, &ScoreSummary{Score: 5}))
+	assert.Equal(t, 1, game.RankingPoints(&ScoreSummary{Score: 10}, &ScoreSummary{Score: 10}))
+	assert.Equal(t, 0, game.RankingPoints(&ScoreSummary{Score: 5}, &ScoreSummary{Score: 10}))
+	assert.Equal(t, 4, game.RankingPoints(&ScoreSummary{Score: 10,
+		GameSummary: StrongholdScoreSummary{Breached: true, Captured: true}}, &ScoreSummary{Score: 5}))
+	assert.Equal(t, 1, game.RankingPoints(&ScoreSummary{Score: 5, GameSummary: StrongholdScoreSummary{Captured: true}},
+		&ScoreSummary{Score: 10}))
+}
+
+func TestStrongholdTiebreakerPoints(t *testing.T) {
+	game := new(Stronghold)
+	summary := &ScoreSummary{AutoPoints: 20, TeleopPoints: 50, Score: 70,
+		GameSummary: StrongholdScoreSummary{DefensePoints: 30, GoalPoints: 25, ScaleChallengePoints: 15}}
+	assert.Equal(t, []int{20, 15, 25, 30}, game.TiebreakerPoints(summary))
+	assert.Equal(t, len(game.Tiebreakers()), len(game.TiebreakerPoints(summary)))
+	assert.Equal(t, "ScaleChallengePoints", game.Tiebreakers()[1].Name)
+}
+
+func TestStrongholdDecodeScore(t *testing.T) {
+	game := new(Stronghold)
+	score, err := game.DecodeScore([]byte("{\"AutoLowGoals\":2,\"DefensesCrossed\":[1,0,2,0,0],\"Fouls\":[]}"))
+	assert.Nil(t, err)
+	assert.Equal(t, StrongholdScore{AutoLowGoals: 2, DefensesCrossed: [5]int{1, 0, 2, 0, 0}}, score)
+	assert.Equal(t, StrongholdScore{}, game.NewScore())
+
+	_, err = game.DecodeScore([]byte("{\"AutoLowGoals\":\"two\"}"))
+	assert.NotNil(t, err)
+}
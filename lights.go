@@ -6,7 +6,6 @@
 package main
 
 import (
-	"log"
 	"net"
 	"time"
 )
@@ -172,7 +171,7 @@ func (lights *Lights) sendLights() {
 			if connection != nil {
 				_, err := (*connection).Write(lights.packets[controller][:])
 				if err != nil {
-					log.Printf("Failed to send %s light packet.", controller)
+					logBuffer.Warning(lightsSubsystem, "Failed to send %s light packet.", controller)
 				}
 			}
 		}
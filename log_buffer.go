@@ -0,0 +1,89 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// In-process buffer of recent log messages from the various subsystems, for display on the Match Play page.
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+const logBufferSize = 200
+
+// Severity levels for log entries.
+const (
+	infoLevel    = "info"
+	warningLevel = "warning"
+	errorLevel   = "error"
+)
+
+// Subsystems that log entries can come from.
+const (
+	arenaSubsystem     = "arena"
+	networkSubsystem   = "network"
+	tbaSubsystem       = "tba"
+	stemTvSubsystem    = "stemtv"
+	lightsSubsystem    = "lights"
+	bandwidthSubsystem = "bandwidth"
+	dsSubsystem        = "ds"
+)
+
+type LogEntry struct {
+	Time      time.Time
+	Level     string
+	Subsystem string
+	Message   string
+}
+
+// Keeps the most recent log entries and notifies listeners of each new one.
+type LogBuffer struct {
+	Notifier *Notifier
+	size     int
+	entries  []LogEntry
+	mutex    sync.Mutex
+}
+
+var logBuffer = NewLogBuffer(logBufferSize)
+
+func NewLogBuffer(size int) *LogBuffer {
+	return &LogBuffer{Notifier: NewNotifier(), size: size}
+}
+
+// Records an entry in the buffer, evicting the oldest one if it is full, and also writes it to the server console.
+func (logBuffer *LogBuffer) Log(level string, subsystem string, format string, args ...interface{}) {
+	entry := LogEntry{Time: time.Now(), Level: level, Subsystem: subsystem, Message: fmt.Sprintf(format, args...)}
+	log.Printf("[%s] %s: %s", subsystem, level, entry.Message)
+
+	logBuffer.mutex.Lock()
+	defer logBuffer.mutex.Unlock()
+	logBuffer.entries = append(logBuffer.entries, entry)
+	if len(logBuffer.entries) > logBuffer.size {
+		logBuffer.entries = logBuffer.entries[len(logBuffer.entries)-logBuffer.size:]
+	}
+	logBuffer.Notifier.Notify(entry)
+}
+
+func (logBuffer *LogBuffer) Info(subsystem string, format string, args ...interface{}) {
+	logBuffer.Log(infoLevel, subsystem, format, args...)
+}
+
+func (logBuffer *LogBuffer) Warning(subsystem string, format string, args ...interface{}) {
+	logBuffer.Log(warningLevel, subsystem, format, args...)
+}
+
+func (logBuffer *LogBuffer) Error(subsystem string, format string, args ...interface{}) {
+	logBuffer.Log(errorLevel, subsystem, format, args...)
+}
+
+// Returns a copy of the buffered entries, oldest first.
+func (logBuffer *LogBuffer) Entries() []LogEntry {
+	logBuffer.mutex.Lock()
+	defer logBuffer.mutex.Unlock()
+	entries := make([]LogEntry, len(logBuffer.entries))
+	copy(entries, logBuffer.entries)
+	return entries
+}
@@ -0,0 +1,42 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLogBuffer(t *testing.T) {
+	buffer := NewLogBuffer(3)
+	listener := buffer.Notifier.Listen()
+	defer close(listener)
+	assert.Empty(t, buffer.Entries())
+
+	buffer.Info(dsSubsystem, "Accepting connection from Team %d in station %s.", 254, "R1")
+	entries := buffer.Entries()
+	if assert.Equal(t, 1, len(entries)) {
+		assert.Equal(t, infoLevel, entries[0].Level)
+		assert.Equal(t, dsSubsystem, entries[0].Subsystem)
+		assert.Equal(t, "Accepting connection from Team 254 in station R1.", entries[0].Message)
+		assert.False(t, entries[0].Time.IsZero())
+	}
+	assert.Equal(t, entries[0], <-listener)
+
+	// Only the most recent entries should be kept once the buffer is full.
+	buffer.Warning(lightsSubsystem, "Warning 1")
+	buffer.Error(tbaSubsystem, "Error 1")
+	buffer.Error(networkSubsystem, "Error 2")
+	entries = buffer.Entries()
+	if assert.Equal(t, 3, len(entries)) {
+		assert.Equal(t, "Warning 1", entries[0].Message)
+		assert.Equal(t, warningLevel, entries[0].Level)
+		assert.Equal(t, "Error 2", entries[2].Message)
+		assert.Equal(t, errorLevel, entries[2].Level)
+	}
+
+	// Modifying the returned entries shouldn't affect the buffer.
+	entries[0].Message = "Changed"
+	assert.Equal(t, "Warning 1", buffer.Entries()[0].Message)
+}
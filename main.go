@@ -4,8 +4,10 @@
 package main
 
 import (
+	"flag"
 	"log"
 	"math/rand"
+	"os"
 	"time"
 )
 
@@ -14,17 +16,30 @@ const eventDbPath = "./event.db"
 var db *Database
 var eventSettings *EventSettings
 
+var simulateDs = flag.Bool("simulate-ds", false, "Start the driver station simulator for rehearsing without robots")
+var dsSimulatorScript = flag.String("ds-simulator-script", "", "File of faults for the driver station simulator")
+
+// Command-line overrides for the driver station network settings.
+var dsListenAddressFlag = flag.String("ds-listen-address", "", "Address to listen on for driver stations")
+var dsListenInterfaceFlag = flag.String("ds-interface", "", "Network interface to listen on for driver stations")
+var dsTcpListenPortFlag = flag.Int("ds-tcp-port", 0, "TCP port to listen on for driver stations")
+var dsUdpReceivePortFlag = flag.Int("ds-udp-receive-port", 0, "UDP port to receive driver station status on")
+var dsUdpSendPortFlag = flag.Int("ds-udp-send-port", 0, "UDP port to send driver station control packets to")
+
 // Main entry point for the application.
 func main() {
+	flag.Parse()
 	rand.Seed(time.Now().UnixNano())
 	initDb()
 
 	// Run the webserver and DS packet listener in goroutines and use the main one for the arena state machine.
 	go ServeWebInterface()
-	go ListenForDriverStations()
-	go ListenForDsUdpPackets()
+	RestartDsListeners()
 	go MonitorBandwidth()
 	mainArena.Setup()
+	if *simulateDs {
+		startDsSimulator(*dsSimulatorScript)
+	}
 	mainArena.Run()
 }
 
@@ -37,6 +52,21 @@ func initDb() {
 	checkErr(err)
 }
 
+// Starts the driver station simulator, loading its faults from the given script file if one is specified.
+func startDsSimulator(scriptPath string) {
+	if scriptPath != "" {
+		scriptFile, err := os.Open(scriptPath)
+		checkErr(err)
+		defer scriptFile.Close()
+		events, err := ParseDsSimulatorScript(scriptFile)
+		checkErr(err)
+		for _, event := range events {
+			checkErr(dsSimulator.AddEvent(event))
+		}
+	}
+	checkErr(dsSimulator.Start(dsListeners.Config()))
+}
+
 // Logs and exits the application if the given error is not nil.
 func checkErr(err error) {
 	if err != nil {
@@ -118,7 +118,7 @@ func (match *Match) TbaCode() string {
 	if match.Type == "qualification" {
 		return fmt.Sprintf("qm%s", match.DisplayName)
 	} else if match.Type == "elimination" {
-		return fmt.Sprintf("%s%dm%d", strings.ToLower(elimRoundNames[match.ElimRound]), match.ElimGroup,
+		return fmt.Sprintf("%s%dm%d", currentEliminationBracket().TbaCompLevel(match.ElimRound), match.ElimGroup,
 			match.ElimInstance)
 	}
 	return ""
@@ -0,0 +1,43 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore CRUD methods for a change in the state of an alliance station during a match.
+
+package main
+
+import (
+	"time"
+)
+
+// Types of match events beyond the connection event types, which are also recorded as match events.
+const (
+	bypassedEvent      = "Bypassed"
+	bypassClearedEvent = "Bypass Cleared"
+	aStopEvent         = "A-Stop"
+)
+
+type MatchEvent struct {
+	Id              int
+	MatchId         int
+	MatchTimeSec    float64
+	DuringMatch     bool
+	AllianceStation string
+	TeamId          int
+	EventType       string
+	Time            time.Time
+}
+
+func (database *Database) CreateMatchEvent(matchEvent *MatchEvent) error {
+	return database.matchEventMap.Insert(matchEvent)
+}
+
+func (database *Database) GetMatchEventsByMatchId(matchId int) ([]MatchEvent, error) {
+	var matchEvents []MatchEvent
+	err := database.matchEventMap.Select(&matchEvents,
+		"SELECT * FROM match_events WHERE matchid = ? ORDER BY time, id", matchId)
+	return matchEvents, err
+}
+
+func (database *Database) TruncateMatchEvents() error {
+	return database.matchEventMap.TruncateTables()
+}
@@ -0,0 +1,39 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMatchEventCrud(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	db, err := OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+
+	event1 := MatchEvent{0, 12, 0, false, "R2", 254, bypassedEvent, time.Unix(1000, 0).UTC()}
+	event2 := MatchEvent{0, 13, 5.5, true, "B1", 1114, emergencyStopEvent, time.Unix(1001, 0).UTC()}
+	event3 := MatchEvent{0, 12, 17.25, true, "R2", 254, robotDisconnectedEvent, time.Unix(1002, 0).UTC()}
+	assert.Nil(t, db.CreateMatchEvent(&event1))
+	assert.Nil(t, db.CreateMatchEvent(&event2))
+	assert.Nil(t, db.CreateMatchEvent(&event3))
+
+	matchEvents, err := db.GetMatchEventsByMatchId(12)
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(matchEvents)) {
+		assert.Equal(t, bypassedEvent, matchEvents[0].EventType)
+		assert.Equal(t, 17.25, matchEvents[1].MatchTimeSec)
+		assert.True(t, matchEvents[1].DuringMatch)
+		assert.True(t, event3.Time.Equal(matchEvents[1].Time))
+	}
+
+	db.TruncateMatchEvents()
+	matchEvents, err = db.GetMatchEventsByMatchId(12)
+	assert.Nil(t, err)
+	assert.Empty(t, matchEvents)
+}
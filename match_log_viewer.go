@@ -0,0 +1,374 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for browsing and charting the team match logs, with automatic flagging of anomalies.
+
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/gorilla/mux"
+	"html/template"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	brownoutVoltageThreshold = 6.8
+	highTripTimeThresholdMs  = 100
+	matchLogChartWidth       = 800
+	matchLogChartHeight      = 100
+)
+
+var matchLogFilenameRe = regexp.MustCompile(`^(\d{14})_(\w+)_Match_(.+)_(\d+)\.csv$`)
+
+// Identifies one team's log file for one match.
+type MatchLogFile struct {
+	Filename         string
+	Time             time.Time
+	MatchType        string
+	MatchDisplayName string
+	TeamId           int
+	MessagesFilename string
+}
+
+// The log files for all of the teams in one match.
+type MatchLogGroup struct {
+	MatchType        string
+	MatchDisplayName string
+	Time             time.Time
+	Files            []MatchLogFile
+}
+
+// One line of a team match log, as written by TeamMatchLog.
+type MatchLogRow struct {
+	MatchTimeSec      float64
+	PacketType        int
+	TeamId            int
+	AllianceStation   string
+	RobotLinked       bool
+	Auto              bool
+	Enabled           bool
+	EmergencyStop     bool
+	BatteryVoltage    float64
+	MissedPacketCount int
+	DsRobotTripTimeMs int
+}
+
+// A period of the match during which something went wrong with the robot or its connection.
+type MatchLogAnomaly struct {
+	StartSec    float64
+	EndSec      float64
+	Description string
+}
+
+// A line chart of one value over the course of the match, with the points scaled to fit the chart dimensions.
+type MatchLogChart struct {
+	Title    string
+	Points   string
+	MaxValue float64
+	MaxTime  float64
+}
+
+// Returns all of the team match logs on disk, grouped by match with the most recent first.
+func ListMatchLogs() ([]MatchLogGroup, error) {
+	fileInfos, err := ioutil.ReadDir(logsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []MatchLogGroup{}, nil
+		}
+		return nil, err
+	}
+
+	filenames := make(map[string]bool)
+	for _, fileInfo := range fileInfos {
+		filenames[fileInfo.Name()] = true
+	}
+
+	var groups []MatchLogGroup
+	groupIndices := make(map[string]int)
+	for _, fileInfo := range fileInfos {
+		logFile, ok := parseMatchLogFilename(fileInfo.Name())
+		if !ok {
+			continue
+		}
+		if messagesFilename := matchLogMessagesFilename(logFile.Filename); filenames[messagesFilename] {
+			logFile.MessagesFilename = messagesFilename
+		}
+		key := logFile.MatchType + "_" + logFile.MatchDisplayName
+		index, ok := groupIndices[key]
+		if !ok {
+			index = len(groups)
+			groupIndices[key] = index
+			groups = append(groups, MatchLogGroup{MatchType: logFile.MatchType,
+				MatchDisplayName: logFile.MatchDisplayName})
+		}
+		groups[index].Files = append(groups[index].Files, *logFile)
+		if logFile.Time.After(groups[index].Time) {
+			groups[index].Time = logFile.Time
+		}
+	}
+	sort.Stable(byMatchLogGroupTime(groups))
+	return groups, nil
+}
+
+// Returns the team match logs for all plays of the given match, oldest first.
+func GetMatchLogFiles(match *Match) ([]MatchLogFile, error) {
+	groups, err := ListMatchLogs()
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		if group.MatchType == match.CapitalizedType() && group.MatchDisplayName == match.DisplayName {
+			return group.Files, nil
+		}
+	}
+	return []MatchLogFile{}, nil
+}
+
+// Extracts the match and team details from the name of a team match log file.
+func parseMatchLogFilename(filename string) (*MatchLogFile, bool) {
+	matches := matchLogFilenameRe.FindStringSubmatch(filename)
+	if matches == nil {
+		return nil, false
+	}
+	logTime, err := time.ParseInLocation("20060102150405", matches[1], time.Local)
+	if err != nil {
+		return nil, false
+	}
+	teamId, _ := strconv.Atoi(matches[4])
+	return &MatchLogFile{Filename: filename, Time: logTime, MatchType: matches[2], MatchDisplayName: matches[3],
+		TeamId: teamId}, true
+}
+
+// Reads and parses the rows of the given team match log file.
+func ReadMatchLog(filename string) ([]MatchLogRow, error) {
+	file, err := os.Open(filepath.Join(logsDir, filename))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	rows := []MatchLogRow{}
+	for i, record := range records {
+		if i == 0 {
+			// Skip the header.
+			continue
+		}
+		if len(record) < 11 {
+			return nil, fmt.Errorf("Line %d of log has %d fields; expected 11.", i+1, len(record))
+		}
+		var row MatchLogRow
+		row.MatchTimeSec, _ = strconv.ParseFloat(record[0], 64)
+		row.PacketType, _ = strconv.Atoi(record[1])
+		row.TeamId, _ = strconv.Atoi(record[2])
+		row.AllianceStation = record[3]
+		row.RobotLinked = record[4] == "true"
+		row.Auto = record[5] == "true"
+		row.Enabled = record[6] == "true"
+		row.EmergencyStop = record[7] == "true"
+		row.BatteryVoltage, _ = strconv.ParseFloat(record[8], 64)
+		row.MissedPacketCount, _ = strconv.Atoi(record[9])
+		row.DsRobotTripTimeMs, _ = strconv.Atoi(record[10])
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// Scans the log rows for brownouts, link drops while enabled and high trip times, merging consecutive rows with
+// the same problem into a single anomaly.
+func DetectMatchLogAnomalies(rows []MatchLogRow) []MatchLogAnomaly {
+	anomalies := []MatchLogAnomaly{}
+	checks := []struct {
+		description string
+		isAnomalous func(row *MatchLogRow) bool
+	}{
+		{fmt.Sprintf("Brownout: battery voltage below %.1fV", brownoutVoltageThreshold),
+			func(row *MatchLogRow) bool {
+				return row.RobotLinked && row.BatteryVoltage > 0 && row.BatteryVoltage < brownoutVoltageThreshold
+			}},
+		{"Robot link lost while enabled", func(row *MatchLogRow) bool {
+			return row.Enabled && !row.RobotLinked
+		}},
+		{fmt.Sprintf("Trip time above %dms", highTripTimeThresholdMs), func(row *MatchLogRow) bool {
+			return row.RobotLinked && row.DsRobotTripTimeMs > highTripTimeThresholdMs
+		}},
+	}
+
+	for _, check := range checks {
+		var current *MatchLogAnomaly
+		for i := range rows {
+			if check.isAnomalous(&rows[i]) {
+				if current == nil {
+					current = &MatchLogAnomaly{StartSec: rows[i].MatchTimeSec, Description: check.description}
+				}
+				current.EndSec = rows[i].MatchTimeSec
+			} else if current != nil {
+				anomalies = append(anomalies, *current)
+				current = nil
+			}
+		}
+		if current != nil {
+			anomalies = append(anomalies, *current)
+		}
+	}
+	sort.Stable(byMatchLogAnomalyStart(anomalies))
+	return anomalies
+}
+
+// Builds the charts of battery voltage, trip time, missed packets and enabled state over the match.
+func buildMatchLogCharts(rows []MatchLogRow) []MatchLogChart {
+	series := []struct {
+		title string
+		value func(row *MatchLogRow) float64
+	}{
+		{"Battery Voltage (V)", func(row *MatchLogRow) float64 { return row.BatteryVoltage }},
+		{"DS-Robot Trip Time (ms)", func(row *MatchLogRow) float64 { return float64(row.DsRobotTripTimeMs) }},
+		{"Missed Packets", func(row *MatchLogRow) float64 { return float64(row.MissedPacketCount) }},
+		{"Enabled", func(row *MatchLogRow) float64 {
+			if row.Enabled {
+				return 1
+			}
+			return 0
+		}},
+	}
+
+	maxTime := 0.0
+	for _, row := range rows {
+		maxTime = math.Max(maxTime, row.MatchTimeSec)
+	}
+	if maxTime == 0 {
+		maxTime = 1
+	}
+
+	charts := make([]MatchLogChart, len(series))
+	for i, s := range series {
+		maxValue := 0.0
+		for j := range rows {
+			maxValue = math.Max(maxValue, s.value(&rows[j]))
+		}
+		if maxValue == 0 {
+			maxValue = 1
+		}
+		points := make([]string, len(rows))
+		for j := range rows {
+			x := rows[j].MatchTimeSec / maxTime * matchLogChartWidth
+			y := matchLogChartHeight - s.value(&rows[j])/maxValue*matchLogChartHeight
+			points[j] = fmt.Sprintf("%.1f,%.1f", x, y)
+		}
+		charts[i] = MatchLogChart{Title: s.title, Points: strings.Join(points, " "), MaxValue: maxValue,
+			MaxTime: maxTime}
+	}
+	return charts
+}
+
+// Orders match log groups with the most recent first.
+type byMatchLogGroupTime []MatchLogGroup
+
+func (groups byMatchLogGroupTime) Len() int {
+	return len(groups)
+}
+
+func (groups byMatchLogGroupTime) Less(i, j int) bool {
+	return groups[i].Time.After(groups[j].Time)
+}
+
+func (groups byMatchLogGroupTime) Swap(i, j int) {
+	groups[i], groups[j] = groups[j], groups[i]
+}
+
+// Orders anomalies by when they began.
+type byMatchLogAnomalyStart []MatchLogAnomaly
+
+func (anomalies byMatchLogAnomalyStart) Len() int {
+	return len(anomalies)
+}
+
+func (anomalies byMatchLogAnomalyStart) Less(i, j int) bool {
+	return anomalies[i].StartSec < anomalies[j].StartSec
+}
+
+func (anomalies byMatchLogAnomalyStart) Swap(i, j int) {
+	anomalies[i], anomalies[j] = anomalies[j], anomalies[i]
+}
+
+// Shows the list of team match logs.
+func MatchLogsGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !UserIsReader(w, r) {
+		return
+	}
+
+	groups, err := ListMatchLogs()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	template, err := template.ParseFiles("templates/match_logs.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*EventSettings
+		MatchLogGroups []MatchLogGroup
+	}{eventSettings, groups}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Shows the charts and anomalies for a single team match log.
+func MatchLogGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !UserIsReader(w, r) {
+		return
+	}
+
+	logFile, ok := parseMatchLogFilename(mux.Vars(r)["filename"])
+	if !ok {
+		handleWebErr(w, fmt.Errorf("Invalid log file name '%s'.", mux.Vars(r)["filename"]))
+		return
+	}
+	rows, err := ReadMatchLog(logFile.Filename)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	messagesFilename := matchLogMessagesFilename(logFile.Filename)
+	if _, err := os.Stat(filepath.Join(logsDir, messagesFilename)); err == nil {
+		logFile.MessagesFilename = messagesFilename
+	}
+	template, err := template.ParseFiles("templates/match_log.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*EventSettings
+		LogFile     *MatchLogFile
+		Charts      []MatchLogChart
+		Anomalies   []MatchLogAnomaly
+		ChartWidth  int
+		ChartHeight int
+	}{eventSettings, logFile, buildMatchLogCharts(rows), DetectMatchLogAnomalies(rows), matchLogChartWidth,
+		matchLogChartHeight}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testMatchLogFilename = "20991231235959_Qualification_Match_12_254.csv"
+
+func writeTestMatchLog(t *testing.T) {
+	os.MkdirAll(logsDir, 0755)
+	contents := "matchTimeSec,packetType,teamId,allianceStation,robotLinked,auto,enabled,emergencyStop," +
+		"batteryVoltage,missedPacketCount,dsRobotTripTimeMs\n" +
+		"0.000000,22,254,R1,true,true,true,false,12.500000,0,5\n" +
+		"0.500000,22,254,R1,true,true,true,false,6.500000,0,5\n" +
+		"1.000000,22,254,R1,true,true,true,false,6.200000,0,5\n" +
+		"1.500000,22,254,R1,false,false,true,false,0.000000,3,0\n" +
+		"2.000000,22,254,R1,true,false,true,false,12.100000,4,150\n" +
+		"2.500000,22,254,R1,true,false,false,false,12.100000,4,5\n"
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(logsDir, testMatchLogFilename), []byte(contents), 0644))
+	messages := "matchTimeSec,time,level,message\n1.200000,10:30:05.000,error,Robot code crashed\n"
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(logsDir, matchLogMessagesFilename(testMatchLogFilename)),
+		[]byte(messages), 0644))
+}
+
+func removeTestMatchLog() {
+	os.Remove(filepath.Join(logsDir, testMatchLogFilename))
+	os.Remove(filepath.Join(logsDir, matchLogMessagesFilename(testMatchLogFilename)))
+}
+
+func TestParseMatchLogFilename(t *testing.T) {
+	logFile, ok := parseMatchLogFilename("20161019103000_Elimination_Match_SF1-2_1114.csv")
+	if assert.True(t, ok) {
+		assert.Equal(t, "Elimination", logFile.MatchType)
+		assert.Equal(t, "SF1-2", logFile.MatchDisplayName)
+		assert.Equal(t, 1114, logFile.TeamId)
+		assert.Equal(t, 2016, logFile.Time.Year())
+	}
+
+	_, ok = parseMatchLogFilename("../../secrets.csv")
+	assert.False(t, ok)
+	_, ok = parseMatchLogFilename("20161019103000_Elimination_Match_SF1-2_1114.txt")
+	assert.False(t, ok)
+}
+
+func TestDetectMatchLogAnomalies(t *testing.T) {
+	writeTestMatchLog(t)
+	defer removeTestMatchLog()
+
+	rows, err := ReadMatchLog(testMatchLogFilename)
+	assert.Nil(t, err)
+	if assert.Equal(t, 6, len(rows)) {
+		assert.Equal(t, MatchLogRow{2, 22, 254, "R1", true, false, true, false, 12.1, 4, 150}, rows[4])
+	}
+
+	anomalies := DetectMatchLogAnomalies(rows)
+	if assert.Equal(t, 3, len(anomalies)) {
+		assert.Equal(t, MatchLogAnomaly{0.5, 1, "Brownout: battery voltage below 6.8V"}, anomalies[0])
+		assert.Equal(t, MatchLogAnomaly{1.5, 1.5, "Robot link lost while enabled"}, anomalies[1])
+		assert.Equal(t, MatchLogAnomaly{2, 2, "Trip time above 100ms"}, anomalies[2])
+	}
+
+	charts := buildMatchLogCharts(rows)
+	if assert.Equal(t, 4, len(charts)) {
+		assert.Equal(t, "Enabled", charts[3].Title)
+		assert.Equal(t, "0.0,0.0 160.0,0.0 320.0,0.0 480.0,0.0 640.0,0.0 800.0,100.0", charts[3].Points)
+	}
+}
+
+func TestMatchLogs(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+	writeTestMatchLog(t)
+	defer removeTestMatchLog()
+
+	groups, err := ListMatchLogs()
+	assert.Nil(t, err)
+	if assert.NotEmpty(t, groups) {
+		assert.Equal(t, "12", groups[0].MatchDisplayName)
+		assert.Equal(t, 254, groups[0].Files[0].TeamId)
+		assert.Equal(t, "20991231235959_Qualification_Match_12_254_messages.csv", groups[0].Files[0].MessagesFilename)
+	}
+	files, err := GetMatchLogFiles(&Match{Type: "qualification", DisplayName: "12"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(files))
+	files, err = GetMatchLogFiles(&Match{Type: "qualification", DisplayName: "13"})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(files))
+
+	recorder := getHttpResponse("/logs")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "/logs/"+testMatchLogFilename)
+
+	recorder = getHttpResponse("/logs/" + testMatchLogFilename)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Team 254")
+	assert.Contains(t, recorder.Body.String(), "Robot link lost while enabled")
+	assert.Contains(t, recorder.Body.String(), "Battery Voltage")
+	assert.Contains(t, recorder.Body.String(), "/static/logs/20991231235959_Qualification_Match_12_254_messages.csv")
+
+	recorder = getHttpResponse("/logs/blorpy.csv")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid log file name")
+}
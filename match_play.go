@@ -163,6 +163,7 @@ func MatchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	user := getRequestUser(r)
 	websocket, err := NewWebsocket(w, r)
 	if err != nil {
 		handleWebErr(w, err)
@@ -172,6 +173,12 @@ func MatchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 
 	matchTimeListener := mainArena.matchTimeNotifier.Listen()
 	defer close(matchTimeListener)
+	matchTimingListener := mainArena.matchTimingNotifier.Listen()
+	defer close(matchTimingListener)
+	timeoutListener := mainArena.timeoutNotifier.Listen()
+	defer close(timeoutListener)
+	fieldResetTimerListener := mainArena.fieldResetTimerNotifier.Listen()
+	defer close(fieldResetTimerListener)
 	realtimeScoreListener := mainArena.realtimeScoreNotifier.Listen()
 	defer close(realtimeScoreListener)
 	robotStatusListener := mainArena.robotStatusNotifier.Listen()
@@ -182,6 +189,8 @@ func MatchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 	defer close(scoringStatusListener)
 	allianceStationDisplayListener := mainArena.allianceStationDisplayNotifier.Listen()
 	defer close(allianceStationDisplayListener)
+	logEntryListener := logBuffer.Notifier.Listen()
+	defer close(logEntryListener)
 
 	// Send the various notifications immediately upon connection.
 	var data interface{}
@@ -195,6 +204,16 @@ func MatchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 		log.Printf("Websocket error: %s", err)
 		return
 	}
+	err = websocket.Write("timeout", mainArena.TimeoutStatus())
+	if err != nil {
+		log.Printf("Websocket error: %s", err)
+		return
+	}
+	err = websocket.Write("fieldResetTimer", mainArena.FieldResetTimerStatus())
+	if err != nil {
+		log.Printf("Websocket error: %s", err)
+		return
+	}
 	data = MatchTimeMessage{mainArena.MatchState, int(mainArena.lastMatchTimeSec)}
 	err = websocket.Write("matchTime", data)
 	if err != nil {
@@ -232,6 +251,11 @@ func MatchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 		log.Printf("Websocket error: %s", err)
 		return
 	}
+	err = websocket.Write("logEntries", logBuffer.Entries())
+	if err != nil {
+		log.Printf("Websocket error: %s", err)
+		return
+	}
 
 	// Spin off a goroutine to listen for notifications and pass them on through the websocket.
 	go func() {
@@ -245,6 +269,24 @@ func MatchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 				}
 				messageType = "matchTime"
 				message = MatchTimeMessage{mainArena.MatchState, matchTimeSec.(int)}
+			case _, ok := <-matchTimingListener:
+				if !ok {
+					return
+				}
+				messageType = "matchTiming"
+				message = mainArena.matchTiming
+			case _, ok := <-timeoutListener:
+				if !ok {
+					return
+				}
+				messageType = "timeout"
+				message = mainArena.TimeoutStatus()
+			case _, ok := <-fieldResetTimerListener:
+				if !ok {
+					return
+				}
+				messageType = "fieldResetTimer"
+				message = mainArena.FieldResetTimerStatus()
 			case _, ok := <-realtimeScoreListener:
 				if !ok {
 					return
@@ -284,6 +326,12 @@ func MatchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 				}
 				messageType = "setAllianceStationDisplay"
 				message = mainArena.allianceStationDisplayScreen
+			case logEntry, ok := <-logEntryListener:
+				if !ok {
+					return
+				}
+				messageType = "logEntry"
+				message = logEntry
 			}
 			err = websocket.Write(messageType, message)
 			if err != nil {
@@ -332,6 +380,12 @@ func MatchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 				continue
 			}
 			mainArena.AllianceStations[station].Bypass = !mainArena.AllianceStations[station].Bypass
+		case "emergencyStop", "aStop":
+			err = handleStationStopCommand(messageType, data, user)
+			if err != nil {
+				websocket.WriteError(err.Error())
+				continue
+			}
 		case "startMatch":
 			args := struct {
 				MuteMatchSounds bool
@@ -347,6 +401,33 @@ func MatchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 				websocket.WriteError(err.Error())
 				continue
 			}
+		case "startAllianceTimeout":
+			alliance, ok := data.(string)
+			if !ok {
+				websocket.WriteError(fmt.Sprintf("Failed to parse '%s' message.", messageType))
+				continue
+			}
+			err = mainArena.StartAllianceTimeout(alliance)
+			if err != nil {
+				websocket.WriteError(err.Error())
+				continue
+			}
+		case "startFieldTimeout":
+			args := struct {
+				DurationSec int
+			}{}
+			err = mapstructure.Decode(data, &args)
+			if err != nil {
+				websocket.WriteError(err.Error())
+				continue
+			}
+			err = mainArena.StartFieldTimeout(args.DurationSec)
+			if err != nil {
+				websocket.WriteError(err.Error())
+				continue
+			}
+		case "clearTimeout":
+			mainArena.ClearTimeout()
 		case "abortMatch":
 			err = mainArena.AbortMatch()
 			if err != nil {
@@ -354,7 +435,7 @@ func MatchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 				continue
 			}
 		case "commitResults":
-			err = CommitCurrentMatchScore()
+			err = CommitCurrentMatchScore(user)
 			if err != nil {
 				websocket.WriteError(err.Error())
 				continue
@@ -424,8 +505,20 @@ func MatchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Applies an emergency stop or autonomous stop command for the station given in the websocket message data.
+func handleStationStopCommand(messageType string, data interface{}, source string) error {
+	station, ok := data.(string)
+	if !ok {
+		return fmt.Errorf("Failed to parse '%s' message.", messageType)
+	}
+	if messageType == "aStop" {
+		return mainArena.AStopStation(station, source)
+	}
+	return mainArena.EmergencyStopStation(station, source)
+}
+
 // Saves the given match and result to the database, supplanting any previous result for the match.
-func CommitMatchScore(match *Match, matchResult *MatchResult, loadToShowBuffer bool) error {
+func CommitMatchScore(match *Match, matchResult *MatchResult, loadToShowBuffer bool, user string) error {
 	if match.Type == "elimination" {
 		// Adjust the score if necessary for an elimination DQ.
 		matchResult.CorrectEliminationScore()
@@ -443,7 +536,10 @@ func CommitMatchScore(match *Match, matchResult *MatchResult, loadToShowBuffer b
 		return nil
 	}
 
+	historyAction := "edit"
 	if matchResult.PlayNumber == 0 {
+		historyAction = "commit"
+
 		// Determine the play number for this new match result.
 		prevMatchResult, err := db.GetMatchResultForMatch(match.Id)
 		if err != nil {
@@ -467,6 +563,10 @@ func CommitMatchScore(match *Match, matchResult *MatchResult, loadToShowBuffer b
 			return err
 		}
 	}
+	err := db.RecordMatchResultHistory(matchResult, historyAction, user)
+	if err != nil {
+		return err
+	}
 
 	// Update and save the match record to the database.
 	match.Status = "complete"
@@ -479,11 +579,38 @@ func CommitMatchScore(match *Match, matchResult *MatchResult, loadToShowBuffer b
 	} else {
 		match.Winner = "T"
 	}
-	err := db.SaveMatch(match)
+	err = db.SaveMatch(match)
 	if err != nil {
 		return err
 	}
 
+	err = updateResultsDependentOnMatch(match)
+	if err != nil {
+		return err
+	}
+
+	if eventSettings.StemTvPublishingEnabled && match.Type != "practice" {
+		// Publish asynchronously to STEMtv.
+		go func() {
+			err = PublishMatchVideoSplit(match, time.Now())
+			if err != nil {
+				logBuffer.Error(stemTvSubsystem, "Failed to publish match video split to STEMtv: %s", err.Error())
+			}
+		}()
+	}
+
+	// Back up the database, but don't error out if it fails.
+	err = db.Backup(fmt.Sprintf("post_%s_match_%s", match.Type, match.DisplayName))
+	if err != nil {
+		logBuffer.Warning(arenaSubsystem, "Failed to back up database: %v", err)
+	}
+
+	return nil
+}
+
+// Regenerates the cards, rankings and elimination schedule that depend on the given match's result, and publishes
+// the updated results if configured to do so.
+func updateResultsDependentOnMatch(match *Match) error {
 	if match.Type != "practice" {
 		// Regenerate the residual yellow cards that teams may carry.
 		db.CalculateTeamCards(match.Type)
@@ -491,7 +618,7 @@ func CommitMatchScore(match *Match, matchResult *MatchResult, loadToShowBuffer b
 
 	if match.Type == "qualification" {
 		// Recalculate all the rankings.
-		err = db.CalculateRankings()
+		err := db.CalculateRankings()
 		if err != nil {
 			return err
 		}
@@ -499,44 +626,29 @@ func CommitMatchScore(match *Match, matchResult *MatchResult, loadToShowBuffer b
 
 	if match.Type == "elimination" {
 		// Generate any subsequent elimination matches.
-		_, err = db.UpdateEliminationSchedule(time.Now().Add(time.Second * elimMatchSpacingSec))
+		_, err := db.UpdateEliminationSchedule(time.Now().Add(time.Second * elimMatchSpacingSec))
 		if err != nil {
 			return err
 		}
+		mainArena.bracketNotifier.Notify(nil)
 	}
 
 	if eventSettings.TbaPublishingEnabled && match.Type != "practice" {
 		// Publish asynchronously to The Blue Alliance.
 		go func() {
-			err = PublishMatches()
+			err := PublishMatches()
 			if err != nil {
-				log.Printf("Failed to publish matches: %s", err.Error())
+				logBuffer.Error(tbaSubsystem, "Failed to publish matches: %s", err.Error())
 			}
 			if match.Type == "qualification" {
 				err = PublishRankings()
 				if err != nil {
-					log.Printf("Failed to publish rankings: %s", err.Error())
+					logBuffer.Error(tbaSubsystem, "Failed to publish rankings: %s", err.Error())
 				}
 			}
 		}()
 	}
 
-	if eventSettings.StemTvPublishingEnabled && match.Type != "practice" {
-		// Publish asynchronously to STEMtv.
-		go func() {
-			err = PublishMatchVideoSplit(match, time.Now())
-			if err != nil {
-				log.Printf("Failed to publish match video split to STEMtv: %s", err.Error())
-			}
-		}()
-	}
-
-	// Back up the database, but don't error out if it fails.
-	err = db.Backup(fmt.Sprintf("post_%s_match_%s", match.Type, match.DisplayName))
-	if err != nil {
-		log.Println(err)
-	}
-
 	return nil
 }
 
@@ -547,8 +659,8 @@ func GetCurrentMatchResult() *MatchResult {
 }
 
 // Saves the realtime result as the final score for the match currently loaded into the arena.
-func CommitCurrentMatchScore() error {
-	return CommitMatchScore(mainArena.currentMatch, GetCurrentMatchResult(), true)
+func CommitCurrentMatchScore(user string) error {
+	return CommitMatchScore(mainArena.currentMatch, GetCurrentMatchResult(), true, user)
 }
 
 // Helper function to implement the required interface for Sort.
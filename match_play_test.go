@@ -150,7 +150,7 @@ func TestCommitMatch(t *testing.T) {
 
 	// Committing test match should do nothing.
 	match := &Match{Id: 0, Type: "test", Red1: 101, Red2: 102, Red3: 103, Blue1: 104, Blue2: 105, Blue3: 106}
-	err = CommitMatchScore(match, &MatchResult{MatchId: match.Id}, false)
+	err = CommitMatchScore(match, &MatchResult{MatchId: match.Id}, false, "admin")
 	assert.Nil(t, err)
 	matchResult, err := db.GetMatchResultForMatch(match.Id)
 	assert.Nil(t, err)
@@ -160,20 +160,20 @@ func TestCommitMatch(t *testing.T) {
 	match.Id = 1
 	match.Type = "qualification"
 	db.CreateMatch(match)
-	matchResult = &MatchResult{MatchId: match.Id, BlueScore: Score{AutoDefensesReached: 2}}
-	err = CommitMatchScore(match, matchResult, false)
+	matchResult = &MatchResult{MatchId: match.Id, BlueScore: Score{GameScore: StrongholdScore{AutoDefensesReached: 2}}}
+	err = CommitMatchScore(match, matchResult, false, "admin")
 	assert.Nil(t, err)
 	assert.Equal(t, 1, matchResult.PlayNumber)
 	match, _ = db.GetMatchById(1)
 	assert.Equal(t, "B", match.Winner)
-	matchResult = &MatchResult{MatchId: match.Id, RedScore: Score{AutoDefensesReached: 1}}
-	err = CommitMatchScore(match, matchResult, false)
+	matchResult = &MatchResult{MatchId: match.Id, RedScore: Score{GameScore: StrongholdScore{AutoDefensesReached: 1}}}
+	err = CommitMatchScore(match, matchResult, false, "admin")
 	assert.Nil(t, err)
 	assert.Equal(t, 2, matchResult.PlayNumber)
 	match, _ = db.GetMatchById(1)
 	assert.Equal(t, "R", match.Winner)
 	matchResult = &MatchResult{MatchId: match.Id}
-	err = CommitMatchScore(match, matchResult, false)
+	err = CommitMatchScore(match, matchResult, false, "admin")
 	assert.Nil(t, err)
 	assert.Equal(t, 3, matchResult.PlayNumber)
 	match, _ = db.GetMatchById(1)
@@ -186,7 +186,7 @@ func TestCommitMatch(t *testing.T) {
 	eventSettings.StemTvPublishingEnabled = true
 	var writer bytes.Buffer
 	log.SetOutput(&writer)
-	err = CommitMatchScore(match, matchResult, false)
+	err = CommitMatchScore(match, matchResult, false, "admin")
 	assert.Nil(t, err)
 	time.Sleep(time.Millisecond * 10) // Allow some time for the asynchronous publishing to happen.
 	assert.Contains(t, writer.String(), "Failed to publish matches")
@@ -206,14 +206,15 @@ func TestCommitEliminationTie(t *testing.T) {
 
 	match := &Match{Id: 0, Type: "qualification", Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5, Blue3: 6}
 	db.CreateMatch(match)
-	matchResult := &MatchResult{MatchId: match.Id, RedScore: Score{HighGoals: 1, Fouls: []Foul{Foul{}}}}
-	err = CommitMatchScore(match, matchResult, false)
+	matchResult := &MatchResult{MatchId: match.Id, RedScore: Score{GameScore: StrongholdScore{HighGoals: 1},
+		Fouls: []Foul{Foul{}}}}
+	err = CommitMatchScore(match, matchResult, false, "admin")
 	assert.Nil(t, err)
 	match, _ = db.GetMatchById(1)
 	assert.Equal(t, "T", match.Winner)
 	match.Type = "elimination"
 	db.SaveMatch(match)
-	CommitMatchScore(match, matchResult, false)
+	CommitMatchScore(match, matchResult, false, "admin")
 	match, _ = db.GetMatchById(1)
 	assert.Equal(t, "T", match.Winner) // No elimination tiebreakers.
 }
@@ -234,21 +235,21 @@ func TestCommitCards(t *testing.T) {
 	match := &Match{Id: 0, Type: "qualification", Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5, Blue3: 6}
 	db.CreateMatch(match)
 	matchResult := &MatchResult{MatchId: match.Id, BlueCards: map[string]string{"5": "yellow"}}
-	err = CommitMatchScore(match, matchResult, false)
+	err = CommitMatchScore(match, matchResult, false, "admin")
 	assert.Nil(t, err)
 	team, _ = db.GetTeamById(5)
 	assert.True(t, team.YellowCard)
 
 	// Check that editing a match result removes a yellow card from a team.
 	matchResult = &MatchResult{MatchId: match.Id}
-	err = CommitMatchScore(match, matchResult, false)
+	err = CommitMatchScore(match, matchResult, false, "admin")
 	assert.Nil(t, err)
 	team, _ = db.GetTeamById(5)
 	assert.False(t, team.YellowCard)
 
 	// Check that a red card causes a yellow card to stick with a team.
 	matchResult = &MatchResult{MatchId: match.Id, BlueCards: map[string]string{"5": "red"}}
-	err = CommitMatchScore(match, matchResult, false)
+	err = CommitMatchScore(match, matchResult, false, "admin")
 	assert.Nil(t, err)
 	team, _ = db.GetTeamById(5)
 	assert.True(t, team.YellowCard)
@@ -259,7 +260,7 @@ func TestCommitCards(t *testing.T) {
 	db.SaveMatch(match)
 	*matchResult = buildTestMatchResult(match.Id, 10)
 	matchResult.RedCards = map[string]string{"1": "red"}
-	err = CommitMatchScore(match, matchResult, false)
+	err = CommitMatchScore(match, matchResult, false, "admin")
 	assert.Nil(t, err)
 	assert.Equal(t, 0, matchResult.RedScoreSummary().Score)
 	assert.Equal(t, 113, matchResult.BlueScoreSummary().Score)
@@ -286,11 +287,23 @@ func TestMatchPlayWebsocketCommands(t *testing.T) {
 	// Should get a few status updates right after connection.
 	readWebsocketType(t, ws, "status")
 	readWebsocketType(t, ws, "matchTiming")
+	readWebsocketType(t, ws, "timeout")
+	readWebsocketType(t, ws, "fieldResetTimer")
 	readWebsocketType(t, ws, "matchTime")
 	readWebsocketType(t, ws, "realtimeScore")
 	readWebsocketType(t, ws, "setAudienceDisplay")
 	readWebsocketType(t, ws, "scoringStatus")
 	readWebsocketType(t, ws, "setAllianceStationDisplay")
+	readWebsocketType(t, ws, "logEntries")
+
+	// Test that new log entries are streamed to the client.
+	logBuffer.Warning(lightsSubsystem, "Failed to send %s light packet.", "red")
+	logEntry, ok := readWebsocketType(t, ws, "logEntry").(map[string]interface{})
+	if assert.True(t, ok) {
+		assert.Equal(t, "warning", logEntry["Level"])
+		assert.Equal(t, "lights", logEntry["Subsystem"])
+		assert.Equal(t, "Failed to send red light packet.", logEntry["Message"])
+	}
 
 	// Test that a server-side error is communicated to the client.
 	ws.Write("nonexistenttype", nil)
@@ -317,6 +330,20 @@ func TestMatchPlayWebsocketCommands(t *testing.T) {
 	ws.Write("toggleBypass", "R3")
 	readWebsocketType(t, ws, "status")
 	assert.Equal(t, false, mainArena.AllianceStations["R3"].Bypass)
+	ws.Write("emergencyStop", "R4")
+	assert.Contains(t, readWebsocketError(t, ws), "Invalid alliance station")
+	ws.Write("aStop", "B2")
+	messages := readWebsocketMultiple(t, ws, 2)
+	_, ok = messages["status"]
+	assert.True(t, ok)
+	_, ok = messages["logEntry"]
+	assert.True(t, ok)
+	assert.Equal(t, true, mainArena.AllianceStations["B2"].AStop)
+	ws.Write("emergencyStop", "B2")
+	readWebsocketMultiple(t, ws, 2)
+	assert.Equal(t, true, mainArena.AllianceStations["B2"].EmergencyStop)
+	mainArena.AllianceStations["B2"].EmergencyStop = false
+	mainArena.AllianceStations["B2"].AStop = false
 
 	// Go through match flow.
 	ws.Write("abortMatch", nil)
@@ -340,12 +367,12 @@ func TestMatchPlayWebsocketCommands(t *testing.T) {
 	readWebsocketType(t, ws, "status")
 	readWebsocketType(t, ws, "setAudienceDisplay")
 	assert.Equal(t, POST_MATCH, mainArena.MatchState)
-	mainArena.redRealtimeScore.CurrentScore.AutoDefensesReached = 1
-	mainArena.blueRealtimeScore.CurrentScore.AutoLowGoals = 2
+	mainArena.redRealtimeScore.CurrentScore.GameScore = StrongholdScore{AutoDefensesReached: 1}
+	mainArena.blueRealtimeScore.CurrentScore.GameScore = StrongholdScore{AutoLowGoals: 2}
 	ws.Write("commitResults", nil)
 	readWebsocketMultiple(t, ws, 3) // reload, realtimeScore, setAllianceStationDisplay
-	assert.Equal(t, 1, mainArena.savedMatchResult.RedScore.AutoDefensesReached)
-	assert.Equal(t, 2, mainArena.savedMatchResult.BlueScore.AutoLowGoals)
+	assert.Equal(t, 1, getStrongholdScore(&mainArena.savedMatchResult.RedScore).AutoDefensesReached)
+	assert.Equal(t, 2, getStrongholdScore(&mainArena.savedMatchResult.BlueScore).AutoLowGoals)
 	assert.Equal(t, PRE_MATCH, mainArena.MatchState)
 	ws.Write("discardResults", nil)
 	readWebsocketMultiple(t, ws, 3) // reload, realtimeScore, setAllianceStationDisplay
@@ -358,6 +385,20 @@ func TestMatchPlayWebsocketCommands(t *testing.T) {
 	ws.Write("setAllianceStationDisplay", "logo")
 	readWebsocketType(t, ws, "setAllianceStationDisplay")
 	assert.Equal(t, "logo", mainArena.allianceStationDisplayScreen)
+
+	// Test timeouts.
+	ws.Write("startAllianceTimeout", "red")
+	assert.Contains(t, readWebsocketError(t, ws), "only be called during the eliminations")
+	ws.Write("startFieldTimeout", map[string]interface{}{"durationSec": 120})
+	messages = readWebsocketMultiple(t, ws, 2)
+	_, ok = messages["timeout"]
+	assert.True(t, ok)
+	assert.Equal(t, "field", mainArena.TimeoutStatus().Type)
+	ws.Write("clearTimeout", nil)
+	messages = readWebsocketMultiple(t, ws, 2)
+	_, ok = messages["timeout"]
+	assert.True(t, ok)
+	assert.Equal(t, "", mainArena.TimeoutStatus().Type)
 }
 
 func TestMatchPlayWebsocketNotifications(t *testing.T) {
@@ -381,10 +422,14 @@ func TestMatchPlayWebsocketNotifications(t *testing.T) {
 	// Should get a few status updates right after connection.
 	readWebsocketType(t, ws, "status")
 	readWebsocketType(t, ws, "matchTiming")
+	readWebsocketType(t, ws, "timeout")
+	readWebsocketType(t, ws, "fieldResetTimer")
 	readWebsocketType(t, ws, "matchTime")
 	readWebsocketType(t, ws, "realtimeScore")
 	readWebsocketType(t, ws, "setAudienceDisplay")
 	readWebsocketType(t, ws, "scoringStatus")
+	readWebsocketType(t, ws, "setAllianceStationDisplay")
+	readWebsocketType(t, ws, "logEntries")
 
 	mainArena.AllianceStations["R1"].Bypass = true
 	mainArena.AllianceStations["R2"].Bypass = true
@@ -394,15 +439,13 @@ func TestMatchPlayWebsocketNotifications(t *testing.T) {
 	mainArena.AllianceStations["B3"].Bypass = true
 	mainArena.StartMatch()
 	mainArena.Update()
-	messages := readWebsocketMultiple(t, ws, 4)
+	messages := readWebsocketMultiple(t, ws, 3)
 	statusReceived, matchTime := getStatusMatchTime(t, messages)
 	assert.Equal(t, true, statusReceived)
 	assert.Equal(t, 2, matchTime.MatchState)
 	assert.Equal(t, 0, matchTime.MatchTimeSec)
 	_, ok := messages["setAudienceDisplay"]
 	assert.True(t, ok)
-	_, ok = messages["setAllianceStationDisplay"]
-	assert.True(t, ok)
 	mainArena.scoringStatusNotifier.Notify(nil)
 	readWebsocketType(t, ws, "scoringStatus")
 
@@ -31,18 +31,12 @@ type MatchResultDb struct {
 	BlueCardsJson string
 }
 
+// An alliance's score for a match. Fouls and disqualifications are common to every game, while everything else is
+// held in the game-specific scoring data.
 type Score struct {
-	AutoDefensesReached int
-	AutoDefensesCrossed [5]int
-	AutoLowGoals        int
-	AutoHighGoals       int
-	DefensesCrossed     [5]int
-	LowGoals            int
-	HighGoals           int
-	Challenges          int
-	Scales              int
-	Fouls               []Foul
-	ElimDq              bool
+	GameScore GameScore
+	Fouls     []Foul
+	ElimDq    bool
 }
 
 type Foul struct {
@@ -53,17 +47,11 @@ type Foul struct {
 }
 
 type ScoreSummary struct {
-	AutoPoints           int
-	DefensePoints        int
-	GoalPoints           int
-	ScaleChallengePoints int
-	TeleopPoints         int
-	FoulPoints           int
-	BonusPoints          int
-	Score                int
-	Breached             bool
-	Captured             bool
-	TowerStrength        int
+	AutoPoints   int
+	TeleopPoints int
+	FoulPoints   int
+	Score        int
+	GameSummary  GameScoreSummary
 }
 
 // Returns a new match result object with empty slices instead of nil.
@@ -122,18 +110,35 @@ func (database *Database) DeleteMatchResult(matchResult *MatchResult) error {
 	return err
 }
 
+// Deletes all results (across every play) for the given match.
+func (database *Database) DeleteMatchResultsForMatch(matchId int) error {
+	_, err := database.matchResultMap.Exec("DELETE FROM match_results WHERE matchid = ?", matchId)
+	return err
+}
+
+// Returns whether any match results have been saved, since their scores are only decodable by the game that
+// produced them.
+func (database *Database) HasMatchResults() (bool, error) {
+	var matchResults []MatchResultDb
+	err := database.matchResultMap.Select(&matchResults, "SELECT * FROM match_results LIMIT 1")
+	if err != nil {
+		return false, err
+	}
+	return len(matchResults) > 0, nil
+}
+
 func (database *Database) TruncateMatchResults() error {
 	return database.matchResultMap.TruncateTables()
 }
 
 // Calculates and returns the summary fields used for ranking and display for the red alliance.
 func (matchResult *MatchResult) RedScoreSummary() *ScoreSummary {
-	return scoreSummary(&matchResult.RedScore, matchResult.BlueScore.Fouls, matchResult.MatchType)
+	return currentGame().ScoreSummary(&matchResult.RedScore, matchResult.BlueScore.Fouls, matchResult.MatchType)
 }
 
 // Calculates and returns the summary fields used for ranking and display for the blue alliance.
 func (matchResult *MatchResult) BlueScoreSummary() *ScoreSummary {
-	return scoreSummary(&matchResult.BlueScore, matchResult.RedScore.Fouls, matchResult.MatchType)
+	return currentGame().ScoreSummary(&matchResult.BlueScore, matchResult.RedScore.Fouls, matchResult.MatchType)
 }
 
 // Checks the score for disqualifications or a tie and adjusts it appropriately.
@@ -153,70 +158,62 @@ func (matchResult *MatchResult) CorrectEliminationScore() {
 	// No elimination tiebreakers in 2016 Chezy Champs rules.
 }
 
-// Calculates and returns the summary fields used for ranking and display.
-func scoreSummary(score *Score, opponentFouls []Foul, matchType string) *ScoreSummary {
-	summary := new(ScoreSummary)
-
-	// Leave the score at zero if the team was disqualified.
-	if score.ElimDq {
-		return summary
+// Encodes the game-specific scoring data as part of the same JSON object as the fouls, so that stored results and the
+// displays see a single flat set of score fields.
+func (score Score) MarshalJSON() ([]byte, error) {
+	gameScore := score.GameScore
+	if gameScore == nil {
+		gameScore = currentGame().NewScore()
 	}
+	return mergeJsonObjects(gameScore, struct {
+		Fouls  []Foul
+		ElimDq bool
+	}{score.Fouls, score.ElimDq})
+}
 
-	// Calculate autonomous score.
-	autoDefensePoints := 0
-	for _, defense := range score.AutoDefensesCrossed {
-		autoDefensePoints += 10 * defense
+func (score *Score) UnmarshalJSON(jsonData []byte) error {
+	var fields struct {
+		Fouls  []Foul
+		ElimDq bool
 	}
-	autoGoalPoints := 5*score.AutoLowGoals + 10*score.AutoHighGoals
-	summary.AutoPoints = 2*score.AutoDefensesReached + autoDefensePoints + autoGoalPoints
-
-	// Calculate teleop score.
-	teleopDefensePoints := 0
-	for _, defense := range score.DefensesCrossed {
-		teleopDefensePoints += 5 * defense
+	if err := json.Unmarshal(jsonData, &fields); err != nil {
+		return err
 	}
-	summary.ScaleChallengePoints = 5*score.Challenges + 15*score.Scales
-	teleopGoalPoints := 2*score.LowGoals + 5*score.HighGoals
-
-	// Calculate tower strength.
-	numTechFouls := 0
-	for _, foul := range score.Fouls {
-		if foul.IsTechnical {
-			numTechFouls++
-		}
+	gameScore, err := currentGame().DecodeScore(jsonData)
+	if err != nil {
+		return err
 	}
-	summary.TowerStrength = eventSettings.InitialTowerStrength + numTechFouls - score.AutoLowGoals -
-		score.AutoHighGoals - score.LowGoals - score.HighGoals
+	*score = Score{gameScore, fields.Fouls, fields.ElimDq}
+	return nil
+}
 
-	// Calculate bonuses.
-	summary.BonusPoints = 0
-	numDefensesDamaged := 0
-	for i := 0; i < 5; i++ {
-		if score.AutoDefensesCrossed[i]+score.DefensesCrossed[i] == 2 {
-			numDefensesDamaged++
+// Encodes the game-specific summary fields as part of the same JSON object as the common ones.
+func (summary ScoreSummary) MarshalJSON() ([]byte, error) {
+	return mergeJsonObjects(summary.GameSummary, struct {
+		AutoPoints   int
+		TeleopPoints int
+		FoulPoints   int
+		Score        int
+	}{summary.AutoPoints, summary.TeleopPoints, summary.FoulPoints, summary.Score})
+}
+
+// Encodes each of the given values as a JSON object and returns a single object containing all of their fields. Nil
+// values are skipped.
+func mergeJsonObjects(values ...interface{}) ([]byte, error) {
+	fields := make(map[string]json.RawMessage)
+	for _, value := range values {
+		if value == nil {
+			continue
 		}
-	}
-	if numDefensesDamaged >= 4 {
-		summary.Breached = true
-		if matchType == "elimination" {
-			summary.BonusPoints += 20
+		jsonData, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
 		}
-	}
-	if score.Challenges+score.Scales == 3 && summary.TowerStrength <= 0 {
-		summary.Captured = true
-		if matchType == "elimination" {
-			summary.BonusPoints += 25
+		if err = json.Unmarshal(jsonData, &fields); err != nil {
+			return nil, err
 		}
 	}
-	summary.TeleopPoints = teleopDefensePoints + teleopGoalPoints + summary.ScaleChallengePoints +
-		summary.BonusPoints
-
-	summary.FoulPoints = 5 * len(opponentFouls)
-	summary.DefensePoints = autoDefensePoints + teleopDefensePoints
-	summary.GoalPoints = autoGoalPoints + teleopGoalPoints
-	summary.Score = summary.AutoPoints + summary.TeleopPoints + summary.FoulPoints
-
-	return summary
+	return json.Marshal(fields)
 }
 
 // Converts the nested struct MatchResult to the DB version that has JSON fields.
@@ -0,0 +1,179 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore methods for the audit trail of every play and edit of a match's results.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Represents a single snapshot of a match result as it was committed, edited, or discarded.
+type MatchResultHistory struct {
+	Id            int
+	MatchId       int
+	PlayNumber    int
+	Action        string
+	User          string
+	Timestamp     time.Time
+	RedScoreJson  string
+	BlueScoreJson string
+	RedCardsJson  string
+	BlueCardsJson string
+}
+
+// Describes a single field that differs between two versions of a match result.
+type MatchResultChange struct {
+	Field    string
+	OldValue string
+	NewValue string
+}
+
+// A history entry along with its changes relative to the previous version of the same play.
+type MatchResultHistoryEntry struct {
+	Timestamp  time.Time
+	User       string
+	Action     string
+	PlayNumber int
+	Result     *MatchResult
+	Changes    []MatchResultChange
+}
+
+// Records a snapshot of the given match result in the audit trail.
+func (database *Database) RecordMatchResultHistory(matchResult *MatchResult, action string, user string) error {
+	matchResultDb, err := matchResult.serialize()
+	if err != nil {
+		return err
+	}
+	history := MatchResultHistory{MatchId: matchResult.MatchId, PlayNumber: matchResult.PlayNumber, Action: action,
+		User: user, Timestamp: time.Now(), RedScoreJson: matchResultDb.RedScoreJson,
+		BlueScoreJson: matchResultDb.BlueScoreJson, RedCardsJson: matchResultDb.RedCardsJson,
+		BlueCardsJson: matchResultDb.BlueCardsJson}
+	return database.matchResultHistoryMap.Insert(&history)
+}
+
+// Returns the audit trail for the given match, in chronological order.
+func (database *Database) GetMatchResultHistory(matchId int) ([]MatchResultHistory, error) {
+	var history []MatchResultHistory
+	err := database.matchResultHistoryMap.Select(&history,
+		"SELECT * FROM match_result_history WHERE matchid = ? ORDER BY id", matchId)
+	return history, err
+}
+
+func (database *Database) TruncateMatchResultHistory() error {
+	return database.matchResultHistoryMap.TruncateTables()
+}
+
+// Builds the list of history entries for the given match, with each edit diffed against the previous version of the
+// same play. Discarding the results resets the baseline for subsequent entries.
+func (database *Database) GetMatchResultHistoryEntries(matchId int) ([]MatchResultHistoryEntry, error) {
+	history, err := database.GetMatchResultHistory(matchId)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]MatchResultHistoryEntry, len(history))
+	previousVersions := make(map[int]map[string]string)
+	for i, version := range history {
+		entries[i] = MatchResultHistoryEntry{Timestamp: version.Timestamp, User: version.User,
+			Action: version.Action, PlayNumber: version.PlayNumber}
+		matchResultDb := MatchResultDb{MatchId: version.MatchId, PlayNumber: version.PlayNumber,
+			RedScoreJson: version.RedScoreJson, BlueScoreJson: version.BlueScoreJson,
+			RedCardsJson: version.RedCardsJson, BlueCardsJson: version.BlueCardsJson}
+		entries[i].Result, err = matchResultDb.deserialize()
+		if err != nil {
+			return nil, err
+		}
+		if version.Action == "unscore" {
+			previousVersions = make(map[int]map[string]string)
+			continue
+		}
+		fields, err := flattenMatchResult(entries[i].Result)
+		if err != nil {
+			return nil, err
+		}
+		if previousFields, ok := previousVersions[version.PlayNumber]; ok {
+			entries[i].Changes = diffFields(previousFields, fields)
+		}
+		previousVersions[version.PlayNumber] = fields
+	}
+	return entries, nil
+}
+
+// Converts the scoring-related parts of the match result into a flat map of field paths to JSON-encoded values.
+func flattenMatchResult(matchResult *MatchResult) (map[string]string, error) {
+	jsonData, err := json.Marshal(struct {
+		RedScore  Score
+		BlueScore Score
+		RedCards  map[string]string
+		BlueCards map[string]string
+	}{matchResult.RedScore, matchResult.BlueScore, matchResult.RedCards, matchResult.BlueCards})
+	if err != nil {
+		return nil, err
+	}
+	var data interface{}
+	err = json.Unmarshal(jsonData, &data)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]string)
+	flattenValue("", data, fields)
+	return fields, nil
+}
+
+func flattenValue(path string, value interface{}, fields map[string]string) {
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		for key, child := range typedValue {
+			if path == "" {
+				flattenValue(key, child, fields)
+			} else {
+				flattenValue(path+"."+key, child, fields)
+			}
+		}
+	case []interface{}:
+		for index, child := range typedValue {
+			flattenValue(fmt.Sprintf("%s[%d]", path, index), child, fields)
+		}
+	case nil:
+		// Leave out empty values so that they compare equal to missing ones.
+	default:
+		jsonValue, _ := json.Marshal(typedValue)
+		fields[path] = string(jsonValue)
+	}
+}
+
+// Returns the fields that differ between the two flattened versions, sorted by field path.
+func diffFields(oldFields map[string]string, newFields map[string]string) []MatchResultChange {
+	changes := []MatchResultChange{}
+	for field, newValue := range newFields {
+		if oldValue, ok := oldFields[field]; !ok || oldValue != newValue {
+			changes = append(changes, MatchResultChange{field, oldFields[field], newValue})
+		}
+	}
+	for field, oldValue := range oldFields {
+		if _, ok := newFields[field]; !ok {
+			changes = append(changes, MatchResultChange{field, oldValue, ""})
+		}
+	}
+	sort.Sort(matchResultChanges(changes))
+	return changes
+}
+
+type matchResultChanges []MatchResultChange
+
+func (changes matchResultChanges) Len() int {
+	return len(changes)
+}
+
+func (changes matchResultChanges) Less(i, j int) bool {
+	return changes[i].Field < changes[j].Field
+}
+
+func (changes matchResultChanges) Swap(i, j int) {
+	changes[i], changes[j] = changes[j], changes[i]
+}
@@ -0,0 +1,43 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMatchResultHistoryCrud(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	db, err := OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+
+	matchResult := buildTestMatchResult(254, 1)
+	assert.Nil(t, db.RecordMatchResultHistory(&matchResult, "commit", "admin"))
+	blueScore := getStrongholdScore(&matchResult.BlueScore)
+	blueScore.Scales = 3
+	matchResult.BlueScore.GameScore = blueScore
+	delete(matchResult.RedCards, "1868")
+	assert.Nil(t, db.RecordMatchResultHistory(&matchResult, "edit", "reader"))
+	history, err := db.GetMatchResultHistory(254)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(history))
+	assert.Equal(t, "commit", history[0].Action)
+	assert.Equal(t, "reader", history[1].User)
+
+	entries, err := db.GetMatchResultHistoryEntries(254)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(entries))
+	assert.Nil(t, entries[0].Changes)
+	assert.Equal(t, []MatchResultChange{{"BlueScore.Scales", "2", "3"}, {"RedCards.1868", "\"yellow\"", ""}},
+		entries[1].Changes)
+	assert.Equal(t, matchResult.BlueScore, entries[1].Result.BlueScore)
+
+	db.TruncateMatchResultHistory()
+	history, err = db.GetMatchResultHistory(254)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(history))
+}
@@ -4,6 +4,7 @@
 package main
 
 import (
+	"encoding/json"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -33,7 +34,7 @@ func TestMatchResultCrud(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, matchResult, *matchResult2)
 
-	matchResult.BlueScore.AutoDefensesReached = 12
+	matchResult.BlueScore.GameScore = StrongholdScore{AutoDefensesReached: 12}
 	db.SaveMatchResult(&matchResult)
 	matchResult2, err = db.GetMatchResultForMatch(254)
 	assert.Nil(t, err)
@@ -90,68 +91,115 @@ func TestScoreSummary(t *testing.T) {
 
 	matchResult := buildTestMatchResult(1, 1)
 	redSummary := matchResult.RedScoreSummary()
+	redStrongholdSummary := getStrongholdSummary(redSummary)
 	assert.Equal(t, 55, redSummary.AutoPoints)
-	assert.Equal(t, 60, redSummary.DefensePoints)
-	assert.Equal(t, 86, redSummary.GoalPoints)
-	assert.Equal(t, 10, redSummary.ScaleChallengePoints)
+	assert.Equal(t, 60, redStrongholdSummary.DefensePoints)
+	assert.Equal(t, 86, redStrongholdSummary.GoalPoints)
+	assert.Equal(t, 10, redStrongholdSummary.ScaleChallengePoints)
 	assert.Equal(t, 101, redSummary.TeleopPoints)
 	assert.Equal(t, 0, redSummary.FoulPoints)
-	assert.Equal(t, 0, redSummary.BonusPoints)
+	assert.Equal(t, 0, redStrongholdSummary.BonusPoints)
 	assert.Equal(t, 156, redSummary.Score)
-	assert.Equal(t, true, redSummary.Breached)
-	assert.Equal(t, false, redSummary.Captured)
-	assert.Equal(t, -5, redSummary.TowerStrength)
+	assert.Equal(t, true, redStrongholdSummary.Breached)
+	assert.Equal(t, false, redStrongholdSummary.Captured)
+	assert.Equal(t, -5, redStrongholdSummary.TowerStrength)
+	assert.Equal(t, [5]int{0, 0, 0, 0, 1}, redStrongholdSummary.DefensesStrength)
 
 	blueSummary := matchResult.BlueScoreSummary()
+	blueStrongholdSummary := getStrongholdSummary(blueSummary)
 	assert.Equal(t, 22, blueSummary.AutoPoints)
-	assert.Equal(t, 25, blueSummary.DefensePoints)
-	assert.Equal(t, 36, blueSummary.GoalPoints)
-	assert.Equal(t, 35, blueSummary.ScaleChallengePoints)
+	assert.Equal(t, 25, blueStrongholdSummary.DefensePoints)
+	assert.Equal(t, 36, blueStrongholdSummary.GoalPoints)
+	assert.Equal(t, 35, blueStrongholdSummary.ScaleChallengePoints)
 	assert.Equal(t, 76, blueSummary.TeleopPoints)
 	assert.Equal(t, 15, blueSummary.FoulPoints)
-	assert.Equal(t, 0, blueSummary.BonusPoints)
+	assert.Equal(t, 0, blueStrongholdSummary.BonusPoints)
 	assert.Equal(t, 113, blueSummary.Score)
-	assert.Equal(t, false, blueSummary.Breached)
-	assert.Equal(t, false, blueSummary.Captured)
-	assert.Equal(t, 1, blueSummary.TowerStrength)
+	assert.Equal(t, false, blueStrongholdSummary.Breached)
+	assert.Equal(t, false, blueStrongholdSummary.Captured)
+	assert.Equal(t, 1, blueStrongholdSummary.TowerStrength)
+	assert.Equal(t, [5]int{1, 0, 2, 2, 1}, blueStrongholdSummary.DefensesStrength)
+
+	redScore := getStrongholdScore(&matchResult.RedScore)
+	blueScore := getStrongholdScore(&matchResult.BlueScore)
+	redStrongholdSummaryFor := func(score StrongholdScore) StrongholdScoreSummary {
+		matchResult.RedScore.GameScore = score
+		return getStrongholdSummary(matchResult.RedScoreSummary())
+	}
+	blueStrongholdSummaryFor := func(score StrongholdScore) StrongholdScoreSummary {
+		matchResult.BlueScore.GameScore = score
+		return getStrongholdSummary(matchResult.BlueScoreSummary())
+	}
 
 	// Test breach boundary conditions.
-	matchResult.RedScore.DefensesCrossed[4] = 2
-	assert.Equal(t, true, matchResult.RedScoreSummary().Breached)
-	matchResult.RedScore.AutoDefensesCrossed[0] = 0
-	assert.Equal(t, true, matchResult.RedScoreSummary().Breached)
-	matchResult.RedScore.DefensesCrossed[1] = 1
-	assert.Equal(t, false, matchResult.RedScoreSummary().Breached)
+	redScore.DefensesCrossed[4] = 2
+	assert.Equal(t, true, redStrongholdSummaryFor(redScore).Breached)
+	redScore.AutoDefensesCrossed[0] = 0
+	assert.Equal(t, true, redStrongholdSummaryFor(redScore).Breached)
+	redScore.DefensesCrossed[1] = 1
+	assert.Equal(t, false, redStrongholdSummaryFor(redScore).Breached)
 
 	// Test capture boundary conditions.
-	matchResult.BlueScore.AutoHighGoals = 1
-	assert.Equal(t, 0, matchResult.BlueScoreSummary().TowerStrength)
-	assert.Equal(t, true, matchResult.BlueScoreSummary().Captured)
-	matchResult.BlueScore.HighGoals = 5
-	assert.Equal(t, true, matchResult.BlueScoreSummary().Captured)
-	matchResult.BlueScore.Challenges = 0
-	assert.Equal(t, false, matchResult.BlueScoreSummary().Captured)
+	blueScore.AutoHighGoals = 1
+	assert.Equal(t, 0, blueStrongholdSummaryFor(blueScore).TowerStrength)
+	assert.Equal(t, true, blueStrongholdSummaryFor(blueScore).Captured)
+	blueScore.HighGoals = 5
+	assert.Equal(t, true, blueStrongholdSummaryFor(blueScore).Captured)
+	blueScore.Challenges = 0
+	assert.Equal(t, false, blueStrongholdSummaryFor(blueScore).Captured)
 
 	// Test elimination bonus.
 	matchResult.MatchType = "elimination"
-	matchResult.RedScore.DefensesCrossed[1] = 2
-	assert.Equal(t, 20, matchResult.RedScoreSummary().BonusPoints)
+	redScore.DefensesCrossed[1] = 2
+	assert.Equal(t, 20, redStrongholdSummaryFor(redScore).BonusPoints)
 	assert.Equal(t, 171, matchResult.RedScoreSummary().Score)
-	matchResult.BlueScore.Challenges = 1
-	assert.Equal(t, 25, matchResult.BlueScoreSummary().BonusPoints)
+	blueScore.Challenges = 1
+	assert.Equal(t, 25, blueStrongholdSummaryFor(blueScore).BonusPoints)
 	assert.Equal(t, 153, matchResult.BlueScoreSummary().Score)
-	matchResult.RedScore.Scales = 1
-	assert.Equal(t, 45, matchResult.RedScoreSummary().BonusPoints)
+	redScore.Scales = 1
+	assert.Equal(t, 45, redStrongholdSummaryFor(redScore).BonusPoints)
 	matchResult.MatchType = "qualification"
-	assert.Equal(t, 0, matchResult.RedScoreSummary().BonusPoints)
-	assert.Equal(t, 0, matchResult.BlueScoreSummary().BonusPoints)
+	assert.Equal(t, 0, redStrongholdSummaryFor(redScore).BonusPoints)
+	assert.Equal(t, 0, blueStrongholdSummaryFor(blueScore).BonusPoints)
+}
+
+func TestScoreJson(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	db, err := OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+
+	// The game-specific fields should be encoded alongside the common ones.
+	matchResult := buildTestMatchResult(1, 1)
+	jsonData, err := json.Marshal(matchResult.BlueScore)
+	assert.Nil(t, err)
+	assert.Equal(t, "{\"AutoDefensesCrossed\":[0,1,0,0,0],\"AutoDefensesReached\":1,\"AutoHighGoals\":0,"+
+		"\"AutoLowGoals\":2,\"Challenges\":1,\"DefensesCrossed\":[1,1,0,0,1],\"ElimDq\":false,\"Fouls\":[],"+
+		"\"HighGoals\":4,\"LowGoals\":3,\"Scales\":2}", string(jsonData))
+	var score Score
+	assert.Nil(t, json.Unmarshal(jsonData, &score))
+	assert.Equal(t, matchResult.BlueScore, score)
+
+	// A score without any game-specific data yet should still encode the blank fields.
+	jsonData, err = json.Marshal(Score{})
+	assert.Nil(t, err)
+	assert.Contains(t, string(jsonData), "\"AutoDefensesCrossed\":[0,0,0,0,0]")
+
+	jsonData, err = json.Marshal(matchResult.BlueScoreSummary())
+	assert.Nil(t, err)
+	assert.Contains(t, string(jsonData), "\"Score\":113")
+	assert.Contains(t, string(jsonData), "\"TowerStrength\":1")
 }
 
 func buildTestMatchResult(matchId int, playNumber int) MatchResult {
 	fouls := []Foul{Foul{25, "G22", false, 25.2}, Foul{25, "G18", true, 150}, Foul{1868, "G20", true, 0}}
 	matchResult := MatchResult{MatchId: matchId, PlayNumber: playNumber, MatchType: "qualification"}
-	matchResult.RedScore = Score{0, [5]int{1, 0, 1, 1, 0}, 1, 2, [5]int{1, 2, 1, 1, 1}, 3, 11, 2, 0, fouls, false}
-	matchResult.BlueScore = Score{1, [5]int{0, 1, 0, 0, 0}, 2, 0, [5]int{1, 1, 0, 0, 1}, 3, 4, 1, 2, []Foul{}, false}
+	matchResult.RedScore = Score{StrongholdScore{0, [5]int{1, 0, 1, 1, 0}, 1, 2, [5]int{1, 2, 1, 1, 1}, 3, 11, 2, 0},
+		fouls, false}
+	matchResult.BlueScore = Score{StrongholdScore{1, [5]int{0, 1, 0, 0, 0}, 2, 0, [5]int{1, 1, 0, 0, 1}, 3, 4, 1, 2},
+		[]Foul{}, false}
 	matchResult.RedCards = map[string]string{"1868": "yellow"}
 	matchResult.BlueCards = map[string]string{}
 	return matchResult
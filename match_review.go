@@ -8,6 +8,7 @@ package main
 import (
 	"fmt"
 	"github.com/gorilla/mux"
+	"log"
 	"net/http"
 	"strconv"
 	"text/template"
@@ -22,6 +23,7 @@ type MatchReviewListItem struct {
 	RedScore    int
 	BlueScore   int
 	ColorClass  string
+	IsComplete  bool
 }
 
 // Shows the match review interface.
@@ -90,11 +92,23 @@ func MatchReviewEditGetHandler(w http.ResponseWriter, r *http.Request) {
 		handleWebErr(w, err)
 		return
 	}
+	matchEvents, err := db.GetMatchEventsByMatchId(match.Id)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	matchLogFiles, err := GetMatchLogFiles(match)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
 	data := struct {
 		*EventSettings
 		Match           *Match
 		MatchResultJson *MatchResultDb
-	}{eventSettings, match, matchResultJson}
+		MatchEvents     []MatchEvent
+		MatchLogFiles   []MatchLogFile
+	}{eventSettings, match, matchResultJson, matchEvents, matchLogFiles}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
 		handleWebErr(w, err)
@@ -136,7 +150,7 @@ func MatchReviewEditPostHandler(w http.ResponseWriter, r *http.Request) {
 
 		http.Redirect(w, r, "/match_play", 302)
 	} else {
-		err = CommitMatchScore(match, matchResult, false)
+		err = CommitMatchScore(match, matchResult, false, getRequestUser(r))
 		if err != nil {
 			handleWebErr(w, err)
 			return
@@ -146,6 +160,130 @@ func MatchReviewEditPostHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Shows the audit trail of every play and edit of a match's results.
+func MatchReviewHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	if !UserIsReader(w, r) {
+		return
+	}
+
+	matchId, _ := strconv.Atoi(mux.Vars(r)["matchId"])
+	match, err := db.GetMatchById(matchId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if match == nil {
+		handleWebErr(w, fmt.Errorf("Error: No such match: %d", matchId))
+		return
+	}
+	entries, err := db.GetMatchResultHistoryEntries(matchId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	template, err := template.ParseFiles("templates/match_review_history.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*EventSettings
+		Match   *Match
+		Entries []MatchResultHistoryEntry
+	}{eventSettings, match, entries}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Discards all results for a match and returns it to the unplayed state.
+func MatchReviewUnscorePostHandler(w http.ResponseWriter, r *http.Request) {
+	if !UserIsAdmin(w, r) {
+		return
+	}
+
+	matchId, _ := strconv.Atoi(mux.Vars(r)["matchId"])
+	match, err := db.GetMatchById(matchId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if match == nil {
+		handleWebErr(w, fmt.Errorf("Error: No such match: %d", matchId))
+		return
+	}
+
+	err = UnscoreMatch(match, getRequestUser(r))
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	http.Redirect(w, r, "/match_review", 302)
+}
+
+// Deletes the results for the given match, marks it as unplayed, and regenerates anything derived from them. A
+// playoff match can't be unscored once a later match that depends on its result has been played; unplayed ones are
+// removed so that the bracket can be rebuilt.
+func UnscoreMatch(match *Match, user string) error {
+	if match.Status != "complete" {
+		return fmt.Errorf("Match %s has not been scored.", match.DisplayName)
+	}
+	var dependentMatches []Match
+	if match.Type == "elimination" {
+		var err error
+		dependentMatches, err = db.GetDependentEliminationMatches(match)
+		if err != nil {
+			return err
+		}
+		for _, dependentMatch := range dependentMatches {
+			if dependentMatch.Status == "complete" {
+				return fmt.Errorf("Cannot unscore match %s because match %s depends on its result and has already "+
+					"been played; unscore match %s first.", match.DisplayName, dependentMatch.DisplayName,
+					dependentMatch.DisplayName)
+			}
+		}
+	}
+
+	// Back up the database first, but don't error out if it fails.
+	err := db.Backup(fmt.Sprintf("pre_unscore_%s_match_%s", match.Type, match.DisplayName))
+	if err != nil {
+		log.Println(err)
+	}
+
+	matchResult, err := db.GetMatchResultForMatch(match.Id)
+	if err != nil {
+		return err
+	}
+	if matchResult != nil {
+		err = db.RecordMatchResultHistory(matchResult, "unscore", user)
+		if err != nil {
+			return err
+		}
+	}
+	err = db.DeleteMatchResultsForMatch(match.Id)
+	if err != nil {
+		return err
+	}
+	match.Status = ""
+	match.Winner = ""
+	err = db.SaveMatch(match)
+	if err != nil {
+		return err
+	}
+	for i := range dependentMatches {
+		err = db.DeleteMatch(&dependentMatches[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return updateResultsDependentOnMatch(match)
+}
+
 // Load the match result for the match referenced in the HTTP query string.
 func getMatchResultFromRequest(r *http.Request) (*Match, *MatchResult, bool, error) {
 	vars := mux.Vars(r)
@@ -204,6 +342,7 @@ func buildMatchReviewList(matchType string) ([]MatchReviewListItem, error) {
 			matchReviewList[i].RedScore = matchResult.RedScoreSummary().Score
 			matchReviewList[i].BlueScore = matchResult.BlueScoreSummary().Score
 		}
+		matchReviewList[i].IsComplete = match.Status == "complete"
 		switch match.Winner {
 		case "R":
 			matchReviewList[i].ColorClass = "danger"
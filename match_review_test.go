@@ -4,9 +4,11 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestMatchReview(t *testing.T) {
@@ -123,3 +125,324 @@ func TestMatchReviewCreateNewResult(t *testing.T) {
 	assert.Contains(t, recorder.Body.String(), "11") // The red score
 	assert.Contains(t, recorder.Body.String(), "15") // The blue score
 }
+
+func TestMatchReviewUnscore(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+
+	match := Match{Type: "qualification", DisplayName: "1", Status: "complete", Winner: "R", Red1: 1001,
+		Red2: 1002, Red3: 1003, Blue1: 1004, Blue2: 1005, Blue3: 1006}
+	db.CreateMatch(&match)
+	matchResult := buildTestMatchResult(match.Id, 1)
+	db.CreateMatchResult(&matchResult)
+	matchResult = buildTestMatchResult(match.Id, 2)
+	db.CreateMatchResult(&matchResult)
+	db.CalculateRankings()
+	rankings, _ := db.GetAllRankings()
+	assert.Equal(t, 6, len(rankings))
+
+	recorder := getHttpResponse("/match_review")
+	assert.Contains(t, recorder.Body.String(), fmt.Sprintf("/match_review/%d/unscore", match.Id))
+
+	recorder = postHttpResponse(fmt.Sprintf("/match_review/%d/unscore", match.Id), "")
+	assert.Equal(t, 302, recorder.Code)
+	match2, _ := db.GetMatchById(match.Id)
+	assert.Equal(t, "", match2.Status)
+	assert.Equal(t, "", match2.Winner)
+	matchResult2, _ := db.GetMatchResultForMatch(match.Id)
+	assert.Nil(t, matchResult2)
+	rankings, _ = db.GetAllRankings()
+	assert.Equal(t, 0, len(rankings))
+	recorder = getHttpResponse("/match_review")
+	assert.NotContains(t, recorder.Body.String(), fmt.Sprintf("/match_review/%d/unscore", match.Id))
+
+	// Check that an unplayed match can't be unscored.
+	recorder = postHttpResponse(fmt.Sprintf("/match_review/%d/unscore", match.Id), "")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "has not been scored")
+
+	// Check response for non-existent match.
+	recorder = postHttpResponse(fmt.Sprintf("/match_review/%d/unscore", 12345), "")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No such match")
+}
+
+func TestUnscoreEliminationMatch(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+	mainArena.Setup()
+
+	createTestAlliances(db, 4)
+	_, err = db.UpdateEliminationSchedule(time.Unix(0, 0))
+	assert.Nil(t, err)
+	scoreMatchWithResult(db, "SF1-1", "R")
+	scoreMatchWithResult(db, "SF1-2", "R")
+	scoreMatchWithResult(db, "SF2-1", "B")
+	scoreMatchWithResult(db, "SF2-2", "B")
+	_, err = db.UpdateEliminationSchedule(time.Unix(0, 0))
+	assert.Nil(t, err)
+	scoreMatchWithResult(db, "F-1", "R")
+	_, err = db.UpdateEliminationSchedule(time.Unix(0, 0))
+	assert.Nil(t, err)
+
+	// A match can't be unscored while a later match that depends on it has been played.
+	match, _ := db.GetMatchByName("elimination", "SF1-2")
+	err = UnscoreMatch(match, "admin")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "match F-1 depends on its result")
+	}
+	match, _ = db.GetMatchByName("elimination", "SF1-2")
+	assert.Equal(t, "complete", match.Status)
+
+	// Once the later match is unscored, the unplayed matches depending on the earlier one should be rebuilt without
+	// the alliance that had advanced.
+	match, _ = db.GetMatchByName("elimination", "F-1")
+	assert.Nil(t, UnscoreMatch(match, "admin"))
+	match, _ = db.GetMatchByName("elimination", "SF1-2")
+	assert.Nil(t, UnscoreMatch(match, "admin"))
+	matches, _ := db.GetMatchesByType("elimination")
+	if assert.Equal(t, 7, len(matches)) {
+		assertMatch(t, matches[0], "SF1-1", 1, 4)
+		assert.Equal(t, "complete", matches[0].Status)
+		assertMatch(t, matches[1], "SF2-1", 2, 3)
+		assertMatch(t, matches[2], "SF1-2", 1, 4)
+		assert.Equal(t, "", matches[2].Status)
+		assertMatch(t, matches[3], "SF2-2", 2, 3)
+		assertMatch(t, matches[4], "F-1", 0, 3)
+		assertMatch(t, matches[5], "F-2", 0, 3)
+		assertMatch(t, matches[6], "F-3", 0, 3)
+	}
+
+	// Rescoring the match should recreate the finals.
+	scoreMatchWithResult(db, "SF1-2", "R")
+	_, err = db.UpdateEliminationSchedule(time.Unix(0, 0))
+	assert.Nil(t, err)
+	match, _ = db.GetMatchByName("elimination", "F-1")
+	if assert.NotNil(t, match) {
+		assertMatch(t, *match, "F-1", 1, 3)
+		assert.Equal(t, "", match.Status)
+	}
+}
+
+func TestUnscoreEliminationMatchInSeries(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+	mainArena.Setup()
+
+	createTestAlliances(db, 4)
+	_, err = db.UpdateEliminationSchedule(time.Unix(0, 0))
+	assert.Nil(t, err)
+	scoreMatchWithResult(db, "SF1-1", "R")
+	scoreMatchWithResult(db, "SF1-2", "B")
+	scoreMatchWithResult(db, "SF1-3", "R")
+	_, err = db.UpdateEliminationSchedule(time.Unix(0, 0))
+	assert.Nil(t, err)
+
+	// Later matches in the series were only played because of the earlier results.
+	match, _ := db.GetMatchByName("elimination", "SF1-2")
+	err = UnscoreMatch(match, "admin")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "match SF1-3 depends on its result")
+	}
+	match, _ = db.GetMatchByName("elimination", "SF1-1")
+	err = UnscoreMatch(match, "admin")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "depends on its result")
+	}
+
+	// Once the tiebreaker is unscored, the earlier matches can be too, without deleting the rest of the series.
+	match, _ = db.GetMatchByName("elimination", "SF1-3")
+	assert.Nil(t, UnscoreMatch(match, "admin"))
+	match, _ = db.GetMatchByName("elimination", "SF1-2")
+	assert.Nil(t, UnscoreMatch(match, "admin"))
+	matches, _ := db.GetMatchesByElimRoundGroup(2, 1)
+	if assert.Equal(t, 3, len(matches)) {
+		assert.Equal(t, "complete", matches[0].Status)
+		assert.Equal(t, "", matches[1].Status)
+		assert.Equal(t, "", matches[2].Status)
+	}
+}
+
+func TestUnscoreRoundRobinMatch(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+	mainArena.Setup()
+	eventSettings.ElimType = "roundrobin"
+	defer func() { eventSettings.ElimType = defaultElimType }()
+
+	createTestAlliances(db, 3)
+	_, err = db.UpdateEliminationSchedule(time.Unix(0, 0))
+	assert.Nil(t, err)
+	for _, displayName := range []string{"RR1", "RR2", "RR3"} {
+		scoreMatchWithResult(db, displayName, "R")
+	}
+	_, err = db.UpdateEliminationSchedule(time.Unix(0, 0))
+	assert.Nil(t, err)
+	matches, _ := db.GetMatchesByType("elimination")
+	assert.Equal(t, 6, len(matches))
+
+	// The finals are seeded from the standings, so they depend on every round-robin match.
+	match, _ := db.GetMatchByName("elimination", "RR2")
+	assert.Nil(t, UnscoreMatch(match, "admin"))
+	matches, _ = db.GetMatchesByType("elimination")
+	if assert.Equal(t, 3, len(matches)) {
+		for _, match := range matches {
+			assert.Equal(t, roundRobinRound, match.ElimRound)
+		}
+	}
+
+	scoreMatchWithResult(db, "RR2", "R")
+	_, err = db.UpdateEliminationSchedule(time.Unix(0, 0))
+	assert.Nil(t, err)
+	scoreMatchWithResult(db, "F-1", "B")
+	match, _ = db.GetMatchByName("elimination", "RR3")
+	err = UnscoreMatch(match, "admin")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "match F-1 depends on its result")
+	}
+}
+
+// Marks the given elimination match as won and gives it a result, as unscoring expects every played match to have.
+func scoreMatchWithResult(db *Database, displayName string, winner string) {
+	scoreMatch(db, displayName, winner)
+	match, _ := db.GetMatchByName("elimination", displayName)
+	db.CreateMatchResult(&MatchResult{MatchId: match.Id, MatchType: match.Type})
+}
+
+func TestMatchReviewHistory(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+
+	match := Match{Type: "qualification", DisplayName: "1", Red1: 1001, Red2: 1002, Red3: 1003, Blue1: 1004,
+		Blue2: 1005, Blue3: 1006}
+	db.CreateMatch(&match)
+	matchResult := buildTestMatchResult(match.Id, 0)
+	assert.Nil(t, CommitMatchScore(&match, &matchResult, false, "admin@10.0.100.1"))
+
+	// Edit the result through the match review interface.
+	postBody := "redScoreJson={\"AutoLowGoals\":5}&blueScoreJson={\"DefensesCrossed\":[2,2,1,2,2]}&" +
+		"redCardsJson={\"1001\":\"yellow\"}&blueCardsJson={}"
+	recorder := postHttpResponse(fmt.Sprintf("/match_review/%d/edit", match.Id), postBody)
+	assert.Equal(t, 302, recorder.Code)
+
+	recorder = getHttpResponse(fmt.Sprintf("/api/matches/%d/results", match.Id))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/json", recorder.HeaderMap["Content-Type"][0])
+	var entries []MatchResultHistoryEntry
+	err = json.Unmarshal([]byte(recorder.Body.String()), &entries)
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(entries)) {
+		assert.Equal(t, "commit", entries[0].Action)
+		assert.Equal(t, "admin@10.0.100.1", entries[0].User)
+		assert.Equal(t, 1, entries[0].PlayNumber)
+		assert.Equal(t, 0, len(entries[0].Changes))
+		assert.Equal(t, "edit", entries[1].Action)
+		assert.Equal(t, "anonymous", entries[1].User)
+		assert.Equal(t, 1, entries[1].PlayNumber)
+		assert.Contains(t, entries[1].Changes, MatchResultChange{"RedScore.AutoLowGoals", "1", "5"})
+		assert.Contains(t, entries[1].Changes, MatchResultChange{"RedCards.1001", "", "\"yellow\""})
+		assert.Contains(t, entries[1].Changes, MatchResultChange{"RedScore.Fouls[0].Rule", "\"G22\"", ""})
+	}
+
+	// Unscoring the match should be recorded and reset the baseline for the next play.
+	recorder = postHttpResponse(fmt.Sprintf("/match_review/%d/unscore", match.Id), "")
+	assert.Equal(t, 302, recorder.Code)
+	match2, _ := db.GetMatchById(match.Id)
+	matchResult = buildTestMatchResult(match.Id, 0)
+	assert.Nil(t, CommitMatchScore(match2, &matchResult, false, "admin@10.0.100.1"))
+	entries, err = db.GetMatchResultHistoryEntries(match.Id)
+	assert.Nil(t, err)
+	if assert.Equal(t, 4, len(entries)) {
+		assert.Equal(t, "unscore", entries[2].Action)
+		assert.Equal(t, 0, len(entries[2].Changes))
+		assert.Equal(t, "commit", entries[3].Action)
+		assert.Equal(t, 0, len(entries[3].Changes))
+	}
+
+	recorder = getHttpResponse(fmt.Sprintf("/match_review/%d/history", match.Id))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Match 1 Result History")
+	assert.Contains(t, recorder.Body.String(), "RedScore.AutoLowGoals: 1 &rarr; 5")
+	assert.Contains(t, recorder.Body.String(), "<td>anonymous</td>")
+
+	// Check response for non-existent match.
+	recorder = getHttpResponse(fmt.Sprintf("/api/matches/%d/results", 12345))
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No such match")
+}
+
+func TestMatchReviewEvents(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+
+	match := Match{Type: "qualification", DisplayName: "12", Red1: 1001, Red2: 1002, Red3: 1003, Blue1: 1004,
+		Blue2: 1005, Blue3: 1006}
+	db.CreateMatch(&match)
+	recorder := getHttpResponse(fmt.Sprintf("/match_review/%d/edit", match.Id))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No events were recorded for this match.")
+
+	db.CreateMatchEvent(&MatchEvent{MatchId: match.Id, MatchTimeSec: 42.5, DuringMatch: true,
+		AllianceStation: "B2", TeamId: 1005, EventType: robotDisconnectedEvent, Time: time.Now()})
+	recorder = getHttpResponse(fmt.Sprintf("/match_review/%d/edit", match.Id))
+	assert.Equal(t, 200, recorder.Code)
+	assert.NotContains(t, recorder.Body.String(), "No events were recorded for this match.")
+	assert.Contains(t, recorder.Body.String(), "42.5s")
+	assert.Contains(t, recorder.Body.String(), "Robot Disconnected")
+}
+
+func TestMatchReviewTeamLogs(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+	mainArena.Setup()
+	writeTestMatchLog(t)
+	defer removeTestMatchLog()
+
+	match := Match{Type: "qualification", DisplayName: "11", Red1: 1114}
+	db.CreateMatch(&match)
+	recorder := getHttpResponse(fmt.Sprintf("/match_review/%d/edit", match.Id))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No team logs were recorded for this match.")
+
+	match = Match{Type: "qualification", DisplayName: "12", Red1: 254}
+	db.CreateMatch(&match)
+	recorder = getHttpResponse(fmt.Sprintf("/match_review/%d/edit", match.Id))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "/logs/"+testMatchLogFilename)
+	assert.Contains(t, recorder.Body.String(), "/static/logs/"+matchLogMessagesFilename(testMatchLogFilename))
+}
@@ -7,14 +7,17 @@ package main
 
 import (
 	"log"
+	"sync"
 )
 
 // Allow the listeners to buffer a small number of notifications to streamline delivery.
 const notifyBufferSize = 3
 
+// Safe for concurrent use, since listeners register from web handlers while notifications come from elsewhere.
 type Notifier struct {
 	// The map is essentially a set; the value is ignored.
 	listeners map[chan interface{}]struct{}
+	mutex     sync.Mutex
 }
 
 func NewNotifier() *Notifier {
@@ -27,12 +30,16 @@ func NewNotifier() *Notifier {
 // responsible for closing the channel, which will cause it to be reaped from the list of listeners.
 func (notifier *Notifier) Listen() chan interface{} {
 	listener := make(chan interface{}, notifyBufferSize)
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
 	notifier.listeners[listener] = struct{}{}
 	return listener
 }
 
 // Sends the given message to all registered listeners, and cleans up any listeners that have closed.
 func (notifier *Notifier) Notify(message interface{}) {
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
 	for listener, _ := range notifier.listeners {
 		notifier.notifyListener(listener, message)
 	}
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"log"
+	"sync"
 	"testing"
 )
 
@@ -82,3 +83,28 @@ func TestNotifyMultipleListeners(t *testing.T) {
 		assert.Equal(t, "message2", <-listener)
 	}
 }
+
+func TestNotifierConcurrentListeners(t *testing.T) {
+	notifier := NewNotifier()
+	log.SetOutput(ioutil.Discard) // Silence noisy log output.
+
+	// Listeners should be able to register while notifications are being sent from other goroutines.
+	var waitGroup sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		waitGroup.Add(2)
+		go func() {
+			defer waitGroup.Done()
+			for j := 0; j < 100; j++ {
+				notifier.Listen()
+			}
+		}()
+		go func() {
+			defer waitGroup.Done()
+			for j := 0; j < 100; j++ {
+				notifier.Notify(j)
+			}
+		}()
+	}
+	waitGroup.Wait()
+	assert.Equal(t, 1000, len(notifier.listeners))
+}
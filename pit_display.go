@@ -25,7 +25,8 @@ func PitDisplayHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	data := struct {
 		*EventSettings
-	}{eventSettings}
+		Tiebreakers []Tiebreaker
+	}{eventSettings, currentGame().Tiebreakers()}
 	err = template.Execute(w, data)
 	if err != nil {
 		handleWebErr(w, err)
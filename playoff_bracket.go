@@ -0,0 +1,126 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model of the current state of the playoff bracket, derived from the alliances and elimination matches.
+
+package main
+
+// The state of the series of matches between two alliances at one point in the bracket. An alliance ID of zero
+// means that the alliance is not yet known.
+type PlayoffSeries struct {
+	Name             string
+	Round            int
+	Group            int
+	RedAllianceId    int
+	RedTeams         []int
+	BlueAllianceId   int
+	BlueTeams        []int
+	RedWins          int
+	BlueWins         int
+	Ties             int
+	WinnerAllianceId int
+	Complete         bool
+	NextMatchName    string
+}
+
+// The state of the whole playoff bracket, with the series listed in the order they are played.
+type PlayoffBracket struct {
+	Format              string
+	Series              []*PlayoffSeries
+	ChampionAllianceId  int
+	RoundRobinStandings []RoundRobinStanding
+}
+
+// Builds the bracket model from the alliance selection results and the elimination matches created so far.
+func (database *Database) GetPlayoffBracket() (*PlayoffBracket, error) {
+	bracketFormat := currentEliminationBracket()
+	playoffBracket := PlayoffBracket{Format: bracketFormat.Name(), Series: []*PlayoffSeries{}}
+
+	alliances, err := database.GetAllAlliances()
+	if err != nil {
+		return nil, err
+	}
+	teamAlliances := make(map[int]int)
+	allianceTeams := make(map[int][]int)
+	for _, alliance := range alliances {
+		for _, allianceTeam := range alliance {
+			teamAlliances[allianceTeam.TeamId] = allianceTeam.AllianceId
+			allianceTeams[allianceTeam.AllianceId] = append(allianceTeams[allianceTeam.AllianceId],
+				allianceTeam.TeamId)
+		}
+	}
+
+	// The matches come back ordered by round and then instance, so each series is first seen in playing order.
+	matches, err := database.GetMatchesByType("elimination")
+	if err != nil {
+		return nil, err
+	}
+	seriesByPosition := make(map[[2]int]*PlayoffSeries)
+	for _, match := range matches {
+		position := [2]int{match.ElimRound, match.ElimGroup}
+		series, ok := seriesByPosition[position]
+		if !ok {
+			series = &PlayoffSeries{Name: bracketFormat.SeriesName(match.ElimRound, match.ElimGroup),
+				Round: match.ElimRound, Group: match.ElimGroup}
+			seriesByPosition[position] = series
+			playoffBracket.Series = append(playoffBracket.Series, series)
+		}
+
+		// Take the alliances from the latest match, since earlier ones may predate a result revision.
+		if allianceId := findAllianceId(teamAlliances, match.Red1, match.Red2, match.Red3); allianceId > 0 {
+			series.RedAllianceId = allianceId
+			series.RedTeams = allianceTeams[allianceId]
+		}
+		if allianceId := findAllianceId(teamAlliances, match.Blue1, match.Blue2, match.Blue3); allianceId > 0 {
+			series.BlueAllianceId = allianceId
+			series.BlueTeams = allianceTeams[allianceId]
+		}
+
+		if match.Status != "complete" {
+			if series.NextMatchName == "" {
+				series.NextMatchName = match.DisplayName
+			}
+			continue
+		}
+		switch match.Winner {
+		case "R":
+			series.RedWins++
+		case "B":
+			series.BlueWins++
+		case "T":
+			series.Ties++
+		}
+	}
+
+	for _, series := range playoffBracket.Series {
+		series.Complete = series.NextMatchName == ""
+		numWinsToAdvance := bracketFormat.NumWinsToAdvance(series.Round)
+		if series.RedWins >= numWinsToAdvance {
+			series.WinnerAllianceId = series.RedAllianceId
+		} else if series.BlueWins >= numWinsToAdvance {
+			series.WinnerAllianceId = series.BlueAllianceId
+		}
+		if series.Round == 1 && series.Group == 1 {
+			playoffBracket.ChampionAllianceId = series.WinnerAllianceId
+		}
+	}
+
+	if _, ok := bracketFormat.(*RoundRobinBracket); ok && len(alliances) > 0 {
+		playoffBracket.RoundRobinStandings, _, err = database.CalculateRoundRobinStandings(len(alliances))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &playoffBracket, nil
+}
+
+// Returns the alliance that any of the given teams belongs to, or zero if none of them are in an alliance.
+func findAllianceId(teamAlliances map[int]int, teamIds ...int) int {
+	for _, teamId := range teamIds {
+		if allianceId, ok := teamAlliances[teamId]; ok && teamId != 0 {
+			return allianceId
+		}
+	}
+	return 0
+}
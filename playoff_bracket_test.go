@@ -0,0 +1,91 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGetPlayoffBracket(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+
+	bracket, err := db.GetPlayoffBracket()
+	assert.Nil(t, err)
+	assert.Equal(t, "Single Elimination", bracket.Format)
+	assert.Equal(t, 0, len(bracket.Series))
+
+	createTestAlliances(db, 4)
+	db.UpdateEliminationSchedule(time.Unix(0, 0))
+	scoreMatch(db, "SF1-1", "R")
+	scoreMatch(db, "SF1-2", "R")
+	scoreMatch(db, "SF2-1", "T")
+	db.UpdateEliminationSchedule(time.Unix(0, 0))
+	bracket, err = db.GetPlayoffBracket()
+	assert.Nil(t, err)
+	if assert.Equal(t, 3, len(bracket.Series)) {
+		assert.Equal(t, PlayoffSeries{"SF1", 2, 1, 1, []int{1, 1, 1}, 4, []int{4, 4, 4}, 2, 0, 0, 1, true, ""},
+			*bracket.Series[0])
+		assert.Equal(t, PlayoffSeries{"SF2", 2, 2, 2, []int{2, 2, 2}, 3, []int{3, 3, 3}, 0, 0, 1, 0, false,
+			"SF2-2"}, *bracket.Series[1])
+		assert.Equal(t, "F", bracket.Series[2].Name)
+		assert.Equal(t, 1, bracket.Series[2].RedAllianceId)
+		assert.Equal(t, 0, bracket.Series[2].BlueAllianceId)
+		assert.Equal(t, "F-1", bracket.Series[2].NextMatchName)
+	}
+	assert.Equal(t, 0, bracket.ChampionAllianceId)
+
+	scoreMatch(db, "SF2-2", "B")
+	scoreMatch(db, "SF2-3", "B")
+	db.UpdateEliminationSchedule(time.Unix(0, 0))
+	scoreMatch(db, "F-1", "B")
+	scoreMatch(db, "F-2", "B")
+	db.UpdateEliminationSchedule(time.Unix(0, 0))
+	bracket, err = db.GetPlayoffBracket()
+	assert.Nil(t, err)
+	if assert.Equal(t, 3, len(bracket.Series)) {
+		assert.Equal(t, 3, bracket.Series[1].WinnerAllianceId)
+		assert.Equal(t, 1, bracket.Series[2].RedAllianceId)
+		assert.Equal(t, 3, bracket.Series[2].BlueAllianceId)
+		assert.True(t, bracket.Series[2].Complete)
+	}
+	assert.Equal(t, 3, bracket.ChampionAllianceId)
+	assert.Nil(t, bracket.RoundRobinStandings)
+}
+
+func TestGetPlayoffBracketRoundRobin(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+	eventSettings.ElimType = "roundrobin"
+	defer func() { eventSettings.ElimType = defaultElimType }()
+
+	createTestAlliances(db, 3)
+	db.UpdateEliminationSchedule(time.Unix(0, 0))
+	scoreMatch(db, "RR1", "B")
+	bracket, err := db.GetPlayoffBracket()
+	assert.Nil(t, err)
+	assert.Equal(t, "Round Robin + Finals", bracket.Format)
+	if assert.Equal(t, 3, len(bracket.Series)) {
+		assert.Equal(t, "RR1", bracket.Series[0].Name)
+		assert.Equal(t, bracket.Series[0].BlueAllianceId, bracket.Series[0].WinnerAllianceId)
+		assert.True(t, bracket.Series[0].Complete)
+		assert.False(t, bracket.Series[1].Complete)
+	}
+	if assert.Equal(t, 3, len(bracket.RoundRobinStandings)) {
+		assert.Equal(t, bracket.Series[0].BlueAllianceId, bracket.RoundRobinStandings[0].AllianceId)
+		assert.Equal(t, 2, bracket.RoundRobinStandings[0].Points)
+	}
+}
@@ -6,50 +6,75 @@
 package main
 
 import (
+	"encoding/json"
 	"math/rand"
 	"sort"
 	"strconv"
 )
 
 type Ranking struct {
-	TeamId               int
-	Rank                 int
-	RankingPoints        int
-	AutoPoints           int
-	ScaleChallengePoints int
-	GoalPoints           int
-	DefensePoints        int
-	Random               float64
-	Wins                 int
-	Losses               int
-	Ties                 int
-	Disqualifications    int
-	Played               int
+	TeamId            int
+	Rank              int
+	RankingPoints     int
+	Tiebreakers       []int
+	Random            float64
+	Wins              int
+	Losses            int
+	Ties              int
+	Disqualifications int
+	Played            int
+}
+
+type RankingDb struct {
+	TeamId            int
+	Rank              int
+	RankingPoints     int
+	TiebreakersJson   string
+	Random            float64
+	Wins              int
+	Losses            int
+	Ties              int
+	Disqualifications int
+	Played            int
 }
 
 type Rankings []*Ranking
 
 func (database *Database) CreateRanking(ranking *Ranking) error {
-	return database.rankingMap.Insert(ranking)
+	rankingDb, err := ranking.serialize()
+	if err != nil {
+		return err
+	}
+	return database.rankingMap.Insert(rankingDb)
 }
 
 func (database *Database) GetRankingForTeam(teamId int) (*Ranking, error) {
-	ranking := new(Ranking)
-	err := database.rankingMap.Get(ranking, teamId)
-	if err != nil && err.Error() == "sql: no rows in result set" {
-		ranking = nil
-		err = nil
+	rankingDb := new(RankingDb)
+	err := database.rankingMap.Get(rankingDb, teamId)
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			err = nil
+		}
+		return nil, err
 	}
-	return ranking, err
+	return rankingDb.deserialize()
 }
 
 func (database *Database) SaveRanking(ranking *Ranking) error {
-	_, err := database.rankingMap.Update(ranking)
+	rankingDb, err := ranking.serialize()
+	if err != nil {
+		return err
+	}
+	_, err = database.rankingMap.Update(rankingDb)
 	return err
 }
 
 func (database *Database) DeleteRanking(ranking *Ranking) error {
-	_, err := database.rankingMap.Delete(ranking)
+	rankingDb, err := ranking.serialize()
+	if err != nil {
+		return err
+	}
+	_, err = database.rankingMap.Delete(rankingDb)
 	return err
 }
 
@@ -58,9 +83,20 @@ func (database *Database) TruncateRankings() error {
 }
 
 func (database *Database) GetAllRankings() ([]Ranking, error) {
+	var rankingDbs []RankingDb
+	err := database.rankingMap.Select(&rankingDbs, "SELECT * FROM rankings ORDER BY rank")
+	if err != nil {
+		return nil, err
+	}
 	var rankings []Ranking
-	err := database.rankingMap.Select(&rankings, "SELECT * FROM rankings ORDER BY rank")
-	return rankings, err
+	for _, rankingDb := range rankingDbs {
+		ranking, err := rankingDb.deserialize()
+		if err != nil {
+			return nil, err
+		}
+		rankings = append(rankings, *ranking)
+	}
+	return rankings, nil
 }
 
 // Determines the rankings from the stored match results, and saves them to the database.
@@ -111,7 +147,11 @@ func (database *Database) CalculateRankings() error {
 	}
 	for rank, ranking := range sortedRankings {
 		ranking.Rank = rank + 1
-		err = transaction.Insert(ranking)
+		rankingDb, err := ranking.serialize()
+		if err != nil {
+			return err
+		}
+		err = transaction.Insert(rankingDb)
 		if err != nil {
 			return err
 		}
@@ -179,7 +219,7 @@ func (database *Database) CalculateTeamCards(matchType string) error {
 func addMatchResultToRankings(rankings map[int]*Ranking, teamId int, matchResult *MatchResult, isRed bool) {
 	ranking := rankings[teamId]
 	if ranking == nil {
-		ranking = &Ranking{TeamId: teamId}
+		ranking = &Ranking{TeamId: teamId, Tiebreakers: make([]int, len(currentGame().Tiebreakers()))}
 		rankings[teamId] = ranking
 	}
 	ranking.Played += 1
@@ -206,27 +246,20 @@ func addMatchResultToRankings(rankings map[int]*Ranking, teamId int, matchResult
 	}
 
 	// Assign ranking points and wins/losses/ties.
+	game := currentGame()
+	ranking.RankingPoints += game.RankingPoints(ownScore, opponentScore)
 	if ownScore.Score > opponentScore.Score {
-		ranking.RankingPoints += 2
 		ranking.Wins += 1
 	} else if ownScore.Score == opponentScore.Score {
-		ranking.RankingPoints += 1
 		ranking.Ties += 1
 	} else {
 		ranking.Losses += 1
 	}
-	if ownScore.Breached {
-		ranking.RankingPoints += 1
-	}
-	if ownScore.Captured {
-		ranking.RankingPoints += 1
-	}
 
 	// Assign tiebreaker points.
-	ranking.AutoPoints += ownScore.AutoPoints
-	ranking.ScaleChallengePoints += ownScore.ScaleChallengePoints
-	ranking.GoalPoints += ownScore.GoalPoints
-	ranking.DefensePoints += ownScore.DefensePoints
+	for i, points := range game.TiebreakerPoints(ownScore) {
+		ranking.Tiebreakers[i] += points
+	}
 
 	// Store a random value to be used as the last tiebreaker if necessary.
 	ranking.Random = rand.Float64()
@@ -251,26 +284,40 @@ func (rankings Rankings) Less(i, j int) bool {
 	a := rankings[i]
 	b := rankings[j]
 
-	// Use cross-multiplication to keep it in integer math.
-	if a.RankingPoints*b.Played == b.RankingPoints*a.Played {
-		if a.AutoPoints*b.Played == b.AutoPoints*a.Played {
-			if a.ScaleChallengePoints*b.Played == b.ScaleChallengePoints*a.Played {
-				if a.GoalPoints*b.Played == b.GoalPoints*a.Played {
-					if a.DefensePoints*b.Played == b.DefensePoints*a.Played {
-						return a.Random > b.Random
-					}
-					return a.DefensePoints*b.Played > b.DefensePoints*a.Played
-				}
-				return a.GoalPoints*b.Played > b.GoalPoints*a.Played
-			}
-			return a.ScaleChallengePoints*b.Played > b.ScaleChallengePoints*a.Played
+	if result := compareAverages(a.RankingPoints, a.Played, b.RankingPoints, b.Played); result != 0 {
+		return result > 0
+	}
+	for i := 0; i < len(a.Tiebreakers) && i < len(b.Tiebreakers); i++ {
+		if result := compareAverages(a.Tiebreakers[i], a.Played, b.Tiebreakers[i], b.Played); result != 0 {
+			return result > 0
 		}
-		return a.AutoPoints*b.Played > b.AutoPoints*a.Played
 	}
-	return a.RankingPoints*b.Played > b.RankingPoints*a.Played
+	return a.Random > b.Random
 }
 
 // Helper function to implement the required interface for Sort.
 func (rankings Rankings) Swap(i, j int) {
 	rankings[i], rankings[j] = rankings[j], rankings[i]
 }
+
+// Converts the Ranking to the DB version that has the tiebreakers as a JSON field.
+func (ranking *Ranking) serialize() (*RankingDb, error) {
+	rankingDb := RankingDb{TeamId: ranking.TeamId, Rank: ranking.Rank, RankingPoints: ranking.RankingPoints,
+		Random: ranking.Random, Wins: ranking.Wins, Losses: ranking.Losses, Ties: ranking.Ties,
+		Disqualifications: ranking.Disqualifications, Played: ranking.Played}
+	if err := serializeHelper(&rankingDb.TiebreakersJson, ranking.Tiebreakers); err != nil {
+		return nil, err
+	}
+	return &rankingDb, nil
+}
+
+// Converts the DB Ranking with the JSON tiebreakers field to the regular version.
+func (rankingDb *RankingDb) deserialize() (*Ranking, error) {
+	ranking := Ranking{TeamId: rankingDb.TeamId, Rank: rankingDb.Rank, RankingPoints: rankingDb.RankingPoints,
+		Random: rankingDb.Random, Wins: rankingDb.Wins, Losses: rankingDb.Losses, Ties: rankingDb.Ties,
+		Disqualifications: rankingDb.Disqualifications, Played: rankingDb.Played}
+	if err := json.Unmarshal([]byte(rankingDb.TiebreakersJson), &ranking.Tiebreakers); err != nil {
+		return nil, err
+	}
+	return &ranking, nil
+}
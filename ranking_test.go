@@ -28,7 +28,7 @@ func TestRankingCrud(t *testing.T) {
 	assert.Nil(t, err)
 	defer db.Close()
 
-	ranking := Ranking{254, 1, 20, 625, 90, 554, 10, 0.254, 3, 2, 1, 0, 10}
+	ranking := Ranking{254, 1, 20, []int{625, 90, 554, 10}, 0.254, 3, 2, 1, 0, 10}
 	db.CreateRanking(&ranking)
 	ranking2, err := db.GetRankingForTeam(254)
 	assert.Nil(t, err)
@@ -53,7 +53,7 @@ func TestTruncateRankings(t *testing.T) {
 	assert.Nil(t, err)
 	defer db.Close()
 
-	ranking := Ranking{254, 1, 20, 625, 90, 554, 10, 0.254, 3, 2, 1, 0, 10}
+	ranking := Ranking{254, 1, 20, []int{625, 90, 554, 10}, 0.254, 3, 2, 1, 0, 10}
 	db.CreateRanking(&ranking)
 	db.TruncateRankings()
 	ranking2, err := db.GetRankingForTeam(254)
@@ -99,12 +99,12 @@ func TestCalculateRankings(t *testing.T) {
 	rankings, err := db.GetAllRankings()
 	assert.Nil(t, err)
 	if assert.Equal(t, 6, len(rankings)) {
-		assert.Equal(t, Ranking{1, 1, 6, 110, 20, 172, 120, 0.2730468047134829, 1, 0, 2, 0, 3}, rankings[0])
-		assert.Equal(t, Ranking{3, 2, 4, 55, 10, 86, 60, 0.9026048462705047, 1, 0, 1, 0, 2}, rankings[1])
-		assert.Equal(t, Ranking{4, 3, 2, 77, 45, 122, 85, 0.897169713149801, 0, 1, 1, 0, 2}, rankings[2])
-		assert.Equal(t, Ranking{5, 4, 3, 77, 45, 122, 85, 0.2885856518054551, 0, 1, 2, 0, 3}, rankings[3])
-		assert.Equal(t, Ranking{2, 5, 3, 55, 10, 86, 60, 0.8497802817628735, 0, 0, 2, 1, 3}, rankings[4])
-		assert.Equal(t, Ranking{6, 6, 1, 22, 35, 36, 25, 0.16735444255905835, 0, 1, 1, 0, 2}, rankings[5])
+		assert.Equal(t, Ranking{1, 1, 6, []int{110, 20, 172, 120}, 0.2730468047134829, 1, 0, 2, 0, 3}, rankings[0])
+		assert.Equal(t, Ranking{3, 2, 4, []int{55, 10, 86, 60}, 0.9026048462705047, 1, 0, 1, 0, 2}, rankings[1])
+		assert.Equal(t, Ranking{4, 3, 2, []int{77, 45, 122, 85}, 0.897169713149801, 0, 1, 1, 0, 2}, rankings[2])
+		assert.Equal(t, Ranking{5, 4, 3, []int{77, 45, 122, 85}, 0.2885856518054551, 0, 1, 2, 0, 3}, rankings[3])
+		assert.Equal(t, Ranking{2, 5, 3, []int{55, 10, 86, 60}, 0.8497802817628735, 0, 0, 2, 1, 3}, rankings[4])
+		assert.Equal(t, Ranking{6, 6, 1, []int{22, 35, 36, 25}, 0.16735444255905835, 0, 1, 1, 0, 2}, rankings[5])
 	}
 
 	// Test after changing a match result.
@@ -117,12 +117,12 @@ func TestCalculateRankings(t *testing.T) {
 	rankings, err = db.GetAllRankings()
 	assert.Nil(t, err)
 	if assert.Equal(t, 6, len(rankings)) {
-		assert.Equal(t, Ranking{3, 1, 6, 110, 20, 172, 120, 0.6930700440076261, 2, 0, 0, 0, 2}, rankings[0])
-		assert.Equal(t, Ranking{1, 2, 8, 165, 30, 258, 180, 0.284824110942037, 2, 0, 1, 0, 3}, rankings[1])
-		assert.Equal(t, Ranking{2, 3, 5, 110, 20, 172, 120, 0.4018978925803393, 1, 0, 1, 1, 3}, rankings[2])
-		assert.Equal(t, Ranking{4, 4, 2, 77, 45, 122, 85, 0.5102423328818813, 0, 1, 1, 0, 2}, rankings[3])
-		assert.Equal(t, Ranking{5, 5, 2, 99, 80, 158, 110, 0.2092018731282357, 0, 2, 1, 0, 3}, rankings[4])
-		assert.Equal(t, Ranking{6, 6, 0, 44, 70, 72, 50, 0.24043190328608438, 0, 2, 0, 0, 2}, rankings[5])
+		assert.Equal(t, Ranking{3, 1, 6, []int{110, 20, 172, 120}, 0.6930700440076261, 2, 0, 0, 0, 2}, rankings[0])
+		assert.Equal(t, Ranking{1, 2, 8, []int{165, 30, 258, 180}, 0.284824110942037, 2, 0, 1, 0, 3}, rankings[1])
+		assert.Equal(t, Ranking{2, 3, 5, []int{110, 20, 172, 120}, 0.4018978925803393, 1, 0, 1, 1, 3}, rankings[2])
+		assert.Equal(t, Ranking{4, 4, 2, []int{77, 45, 122, 85}, 0.5102423328818813, 0, 1, 1, 0, 2}, rankings[3])
+		assert.Equal(t, Ranking{5, 5, 2, []int{99, 80, 158, 110}, 0.2092018731282357, 0, 2, 1, 0, 3}, rankings[4])
+		assert.Equal(t, Ranking{6, 6, 0, []int{44, 70, 72, 50}, 0.24043190328608438, 0, 2, 0, 0, 2}, rankings[5])
 	}
 }
 
@@ -135,18 +135,18 @@ func TestSortRankings(t *testing.T) {
 
 	// Check tiebreakers.
 	rankings := make(map[int]*Ranking)
-	rankings[1] = &Ranking{1, 0, 50, 50, 50, 50, 50, 0.49, 3, 2, 1, 0, 10}
-	rankings[2] = &Ranking{2, 0, 50, 50, 50, 50, 50, 0.51, 3, 2, 1, 0, 10}
-	rankings[3] = &Ranking{3, 0, 50, 50, 50, 50, 49, 0.50, 3, 2, 1, 0, 10}
-	rankings[4] = &Ranking{4, 0, 50, 50, 50, 50, 51, 0.50, 3, 2, 1, 0, 10}
-	rankings[5] = &Ranking{5, 0, 50, 50, 50, 49, 50, 0.50, 3, 2, 1, 0, 10}
-	rankings[6] = &Ranking{6, 0, 50, 50, 50, 51, 50, 0.50, 3, 2, 1, 0, 10}
-	rankings[7] = &Ranking{7, 0, 50, 50, 49, 50, 50, 0.50, 3, 2, 1, 0, 10}
-	rankings[8] = &Ranking{8, 0, 50, 50, 51, 50, 50, 0.50, 3, 2, 1, 0, 10}
-	rankings[9] = &Ranking{9, 0, 50, 49, 50, 50, 50, 0.50, 3, 2, 1, 0, 10}
-	rankings[10] = &Ranking{10, 0, 50, 51, 50, 50, 50, 0.50, 3, 2, 1, 0, 10}
-	rankings[11] = &Ranking{11, 0, 49, 50, 50, 50, 50, 0.50, 3, 2, 1, 0, 10}
-	rankings[12] = &Ranking{12, 0, 51, 50, 50, 50, 50, 0.50, 3, 2, 1, 0, 10}
+	rankings[1] = &Ranking{1, 0, 50, []int{50, 50, 50, 50}, 0.49, 3, 2, 1, 0, 10}
+	rankings[2] = &Ranking{2, 0, 50, []int{50, 50, 50, 50}, 0.51, 3, 2, 1, 0, 10}
+	rankings[3] = &Ranking{3, 0, 50, []int{50, 50, 50, 49}, 0.50, 3, 2, 1, 0, 10}
+	rankings[4] = &Ranking{4, 0, 50, []int{50, 50, 50, 51}, 0.50, 3, 2, 1, 0, 10}
+	rankings[5] = &Ranking{5, 0, 50, []int{50, 50, 49, 50}, 0.50, 3, 2, 1, 0, 10}
+	rankings[6] = &Ranking{6, 0, 50, []int{50, 50, 51, 50}, 0.50, 3, 2, 1, 0, 10}
+	rankings[7] = &Ranking{7, 0, 50, []int{50, 49, 50, 50}, 0.50, 3, 2, 1, 0, 10}
+	rankings[8] = &Ranking{8, 0, 50, []int{50, 51, 50, 50}, 0.50, 3, 2, 1, 0, 10}
+	rankings[9] = &Ranking{9, 0, 50, []int{49, 50, 50, 50}, 0.50, 3, 2, 1, 0, 10}
+	rankings[10] = &Ranking{10, 0, 50, []int{51, 50, 50, 50}, 0.50, 3, 2, 1, 0, 10}
+	rankings[11] = &Ranking{11, 0, 49, []int{50, 50, 50, 50}, 0.50, 3, 2, 1, 0, 10}
+	rankings[12] = &Ranking{12, 0, 51, []int{50, 50, 50, 50}, 0.50, 3, 2, 1, 0, 10}
 	sortedRankings := sortRankings(rankings)
 	assert.Equal(t, 12, sortedRankings[0].TeamId)
 	assert.Equal(t, 10, sortedRankings[1].TeamId)
@@ -163,9 +163,9 @@ func TestSortRankings(t *testing.T) {
 
 	// Check with unequal number of matches played.
 	rankings = make(map[int]*Ranking)
-	rankings[1] = &Ranking{1, 0, 10, 25, 25, 25, 25, 0.49, 3, 2, 1, 0, 5}
-	rankings[2] = &Ranking{2, 0, 19, 50, 50, 50, 50, 0.51, 3, 2, 1, 0, 9}
-	rankings[3] = &Ranking{3, 0, 20, 50, 50, 50, 50, 0.51, 3, 2, 1, 0, 10}
+	rankings[1] = &Ranking{1, 0, 10, []int{25, 25, 25, 25}, 0.49, 3, 2, 1, 0, 5}
+	rankings[2] = &Ranking{2, 0, 19, []int{50, 50, 50, 50}, 0.51, 3, 2, 1, 0, 9}
+	rankings[3] = &Ranking{3, 0, 20, []int{50, 50, 50, 50}, 0.51, 3, 2, 1, 0, 10}
 	sortedRankings = sortRankings(rankings)
 	assert.Equal(t, 2, sortedRankings[0].TeamId)
 	assert.Equal(t, 3, sortedRankings[1].TeamId)
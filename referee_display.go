@@ -108,6 +108,15 @@ func RefereeDisplayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 	defer close(matchLoadTeamsListener)
 	reloadDisplaysListener := mainArena.reloadDisplaysNotifier.Listen()
 	defer close(reloadDisplaysListener)
+	fieldResetTimerListener := mainArena.fieldResetTimerNotifier.Listen()
+	defer close(fieldResetTimerListener)
+
+	// Send the various notifications immediately upon connection.
+	err = websocket.Write("fieldResetTimer", mainArena.FieldResetTimerStatus())
+	if err != nil {
+		log.Printf("Websocket error: %s", err)
+		return
+	}
 
 	// Spin off a goroutine to listen for notifications and pass them on through the websocket.
 	go func() {
@@ -127,6 +136,12 @@ func RefereeDisplayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 				}
 				messageType = "reload"
 				message = nil
+			case _, ok := <-fieldResetTimerListener:
+				if !ok {
+					return
+				}
+				messageType = "fieldResetTimer"
+				message = mainArena.FieldResetTimerStatus()
 			}
 			err = websocket.Write(messageType, message)
 			if err != nil {
@@ -229,6 +244,9 @@ func RefereeDisplayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 				// Don't allow clearing the field until the match is over.
 				continue
 			}
+			if !mainArena.fieldReset {
+				mainArena.startFieldResetTimer()
+			}
 			mainArena.fieldReset = true
 			mainArena.allianceStationDisplayScreen = "fieldReset"
 			mainArena.allianceStationDisplayNotifier.Notify(nil)
@@ -240,6 +258,9 @@ func RefereeDisplayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 			}
 			mainArena.redRealtimeScore.FoulsCommitted = true
 			mainArena.blueRealtimeScore.FoulsCommitted = true
+			if !mainArena.fieldReset {
+				mainArena.startFieldResetTimer()
+			}
 			mainArena.fieldReset = true
 			mainArena.allianceStationDisplayScreen = "fieldReset"
 			mainArena.allianceStationDisplayNotifier.Notify(nil)
@@ -42,6 +42,7 @@ func TestRefereeDisplayWebsocket(t *testing.T) {
 	assert.Nil(t, err)
 	defer conn.Close()
 	ws := &Websocket{conn, new(sync.Mutex)}
+	readWebsocketType(t, ws, "fieldResetTimer")
 
 	// Test foul addition.
 	foulData := struct {
@@ -116,7 +117,7 @@ func TestRefereeDisplayWebsocket(t *testing.T) {
 	// Test field reset and match committing.
 	mainArena.MatchState = POST_MATCH
 	ws.Write("signalReset", nil)
-	time.Sleep(time.Millisecond * 10)
+	readWebsocketType(t, ws, "fieldResetTimer")
 	assert.Equal(t, "fieldReset", mainArena.allianceStationDisplayScreen)
 	assert.False(t, mainArena.redRealtimeScore.FoulsCommitted)
 	assert.False(t, mainArena.blueRealtimeScore.FoulsCommitted)
@@ -126,6 +127,7 @@ func TestRefereeDisplayWebsocket(t *testing.T) {
 	assert.Equal(t, "fieldReset", mainArena.allianceStationDisplayScreen)
 	assert.True(t, mainArena.redRealtimeScore.FoulsCommitted)
 	assert.True(t, mainArena.blueRealtimeScore.FoulsCommitted)
+	assert.True(t, mainArena.FieldResetTimerStatus().Running)
 
 	// Should refresh the page when the next match is loaded.
 	mainArena.matchLoadTeamsNotifier.Notify(nil)
@@ -33,7 +33,11 @@ func RankingsCsvReportHandler(w http.ResponseWriter, r *http.Request) {
 		handleWebErr(w, err)
 		return
 	}
-	err = template.Execute(w, rankings)
+	data := struct {
+		Tiebreakers []Tiebreaker
+		Rankings    []Ranking
+	}{currentGame().Tiebreakers(), rankings}
+	err = template.Execute(w, data)
 	if err != nil {
 		handleWebErr(w, err)
 		return
@@ -52,9 +56,11 @@ func RankingsPdfReportHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// The widths of the table columns in mm, stored here so that they can be referenced for each row.
-	colWidths := map[string]float64{"Rank": 13, "Team": 23, "RP": 20, "Auto": 20, "SC": 21, "Goal": 20,
-		"Defense": 20, "W-L-T": 20, "DQ": 20, "Played": 20}
+	// The widths of the table columns in mm, stored here so that they can be referenced for each row. The game's
+	// tiebreakers share the space left over by the fixed columns.
+	colWidths := map[string]float64{"Rank": 13, "Team": 23, "RP": 20, "W-L-T": 20, "DQ": 20, "Played": 20}
+	tiebreakers := currentGame().Tiebreakers()
+	tiebreakerWidth := 81 / float64(len(tiebreakers))
 	rowHeight := 6.5
 
 	pdf := gofpdf.New("P", "mm", "Letter", "font")
@@ -67,10 +73,9 @@ func RankingsPdfReportHandler(w http.ResponseWriter, r *http.Request) {
 	pdf.CellFormat(colWidths["Rank"], rowHeight, "Rank", "1", 0, "C", true, 0, "")
 	pdf.CellFormat(colWidths["Team"], rowHeight, "Team", "1", 0, "C", true, 0, "")
 	pdf.CellFormat(colWidths["RP"], rowHeight, "RB", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(colWidths["Auto"], rowHeight, "Auto", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(colWidths["SC"], rowHeight, "Scale/Chal.", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(colWidths["Goal"], rowHeight, "Goal", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(colWidths["Defense"], rowHeight, "Defense", "1", 0, "C", true, 0, "")
+	for _, tiebreaker := range tiebreakers {
+		pdf.CellFormat(tiebreakerWidth, rowHeight, tiebreaker.ShortLabel, "1", 0, "C", true, 0, "")
+	}
 	pdf.CellFormat(colWidths["W-L-T"], rowHeight, "W-L-T", "1", 0, "C", true, 0, "")
 	pdf.CellFormat(colWidths["DQ"], rowHeight, "DQ", "1", 0, "C", true, 0, "")
 	pdf.CellFormat(colWidths["Played"], rowHeight, "Played", "1", 1, "C", true, 0, "")
@@ -81,10 +86,13 @@ func RankingsPdfReportHandler(w http.ResponseWriter, r *http.Request) {
 		pdf.SetFont("Arial", "", 10)
 		pdf.CellFormat(colWidths["Team"], rowHeight, strconv.Itoa(ranking.TeamId), "1", 0, "C", false, 0, "")
 		pdf.CellFormat(colWidths["RP"], rowHeight, strconv.Itoa(ranking.RankingPoints), "1", 0, "C", false, 0, "")
-		pdf.CellFormat(colWidths["Auto"], rowHeight, strconv.Itoa(ranking.AutoPoints), "1", 0, "C", false, 0, "")
-		pdf.CellFormat(colWidths["SC"], rowHeight, strconv.Itoa(ranking.ScaleChallengePoints), "1", 0, "C", false, 0, "")
-		pdf.CellFormat(colWidths["Goal"], rowHeight, strconv.Itoa(ranking.GoalPoints), "1", 0, "C", false, 0, "")
-		pdf.CellFormat(colWidths["Defense"], rowHeight, strconv.Itoa(ranking.DefensePoints), "1", 0, "C", false, 0, "")
+		for i := range tiebreakers {
+			points := ""
+			if i < len(ranking.Tiebreakers) {
+				points = strconv.Itoa(ranking.Tiebreakers[i])
+			}
+			pdf.CellFormat(tiebreakerWidth, rowHeight, points, "1", 0, "C", false, 0, "")
+		}
 		record := fmt.Sprintf("%d-%d-%d", ranking.Wins, ranking.Losses, ranking.Ties)
 		pdf.CellFormat(colWidths["W-L-T"], rowHeight, record, "1", 0, "C", false, 0, "")
 		pdf.CellFormat(colWidths["DQ"], rowHeight, strconv.Itoa(ranking.Disqualifications), "1", 0, "C", false, 0, "")
@@ -370,6 +378,89 @@ func TeamsPdfReportHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Generates a PDF-formatted report of the playoff bracket, showing the result of each series.
+func BracketPdfReportHandler(w http.ResponseWriter, r *http.Request) {
+	if !UserIsReader(w, r) {
+		return
+	}
+
+	bracket, err := db.GetPlayoffBracket()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// The widths of the table columns in mm, stored here so that they can be referenced for each row.
+	colWidths := map[string]float64{"Series": 20, "Alliance": 55, "Result": 25, "Status": 40}
+	rowHeight := 6.5
+
+	pdf := gofpdf.New("P", "mm", "Letter", "font")
+	pdf.AddPage()
+	pdf.SetFont("Arial", "B", 10)
+	pdf.SetFillColor(220, 220, 220)
+
+	// Render table header row.
+	pdf.CellFormat(195, rowHeight, fmt.Sprintf("Playoff Bracket (%s) - %s", bracket.Format, eventSettings.Name), "",
+		1, "C", false, 0, "")
+	pdf.CellFormat(colWidths["Series"], rowHeight, "Series", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Alliance"], rowHeight, "Red Alliance", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Alliance"], rowHeight, "Blue Alliance", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Result"], rowHeight, "W-L-T", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Status"], rowHeight, "Status", "1", 1, "C", true, 0, "")
+	for _, series := range bracket.Series {
+		// Render series info row, highlighting the winner in bold.
+		pdf.SetFont("Arial", "B", 10)
+		pdf.CellFormat(colWidths["Series"], rowHeight, series.Name, "1", 0, "C", false, 0, "")
+		setBracketAllianceFont(pdf, series, series.RedAllianceId)
+		pdf.CellFormat(colWidths["Alliance"], rowHeight, bracketAllianceText(series.RedAllianceId, series.RedTeams),
+			"1", 0, "C", false, 0, "")
+		setBracketAllianceFont(pdf, series, series.BlueAllianceId)
+		pdf.CellFormat(colWidths["Alliance"], rowHeight, bracketAllianceText(series.BlueAllianceId,
+			series.BlueTeams), "1", 0, "C", false, 0, "")
+		pdf.SetFont("Arial", "", 10)
+		record := fmt.Sprintf("%d-%d-%d", series.RedWins, series.BlueWins, series.Ties)
+		pdf.CellFormat(colWidths["Result"], rowHeight, record, "1", 0, "C", false, 0, "")
+		status := "Complete"
+		if series.WinnerAllianceId > 0 {
+			status = fmt.Sprintf("Alliance %d wins", series.WinnerAllianceId)
+		} else if !series.Complete {
+			status = "Next: " + series.NextMatchName
+		}
+		pdf.CellFormat(colWidths["Status"], rowHeight, status, "1", 1, "C", false, 0, "")
+	}
+
+	if len(bracket.RoundRobinStandings) > 0 {
+		// Render the round-robin standings table.
+		pdf.Ln(rowHeight)
+		pdf.SetFont("Arial", "B", 10)
+		pdf.CellFormat(195, rowHeight, "Round-Robin Standings", "", 1, "C", false, 0, "")
+		pdf.CellFormat(colWidths["Series"], rowHeight, "Rank", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colWidths["Alliance"], rowHeight, "Alliance", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colWidths["Result"], rowHeight, "W-L-T", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colWidths["Result"], rowHeight, "Score", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(colWidths["Result"], rowHeight, "Points", "1", 1, "C", true, 0, "")
+		pdf.SetFont("Arial", "", 10)
+		for i, standing := range bracket.RoundRobinStandings {
+			pdf.CellFormat(colWidths["Series"], rowHeight, strconv.Itoa(i+1), "1", 0, "C", false, 0, "")
+			pdf.CellFormat(colWidths["Alliance"], rowHeight, fmt.Sprintf("Alliance %d", standing.AllianceId), "1", 0,
+				"C", false, 0, "")
+			record := fmt.Sprintf("%d-%d-%d", standing.Wins, standing.Losses, standing.Ties)
+			pdf.CellFormat(colWidths["Result"], rowHeight, record, "1", 0, "C", false, 0, "")
+			pdf.CellFormat(colWidths["Result"], rowHeight, strconv.Itoa(standing.TotalScore), "1", 0, "C", false, 0,
+				"")
+			pdf.CellFormat(colWidths["Result"], rowHeight, strconv.Itoa(standing.Points), "1", 1, "C", false, 0, "")
+		}
+	}
+
+	// Write out the PDF file as the HTTP response.
+	w.Header().Set("Content-Type", "application/pdf")
+	err = pdf.Output(w)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
 // Generates a CSV-formatted report of the WPA keys, for import into the radio kiosk.
 func WpaKeysCsvReportHandler(w http.ResponseWriter, r *http.Request) {
 	if !UserIsAdmin(w, r) {
@@ -393,6 +484,27 @@ func WpaKeysCsvReportHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Returns the text describing an alliance in the bracket report, or a placeholder if it is not yet known.
+func bracketAllianceText(allianceId int, teams []int) string {
+	if allianceId == 0 {
+		return "TBD"
+	}
+	text := fmt.Sprintf("A%d:", allianceId)
+	for _, team := range teams {
+		text += " " + strconv.Itoa(team)
+	}
+	return text
+}
+
+// Sets the font for an alliance cell in the bracket report, using bold for the winner of the series.
+func setBracketAllianceFont(pdf *gofpdf.Fpdf, series *PlayoffSeries, allianceId int) {
+	if allianceId != 0 && allianceId == series.WinnerAllianceId {
+		pdf.SetFont("Arial", "B", 10)
+	} else {
+		pdf.SetFont("Arial", "", 10)
+	}
+}
+
 // Returns the text to display if a team is a surrogate.
 func surrogateText(isSurrogate bool) string {
 	if isSurrogate {
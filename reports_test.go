@@ -13,8 +13,8 @@ func TestRankingsCsvReport(t *testing.T) {
 	clearDb()
 	defer clearDb()
 	db, _ = OpenDatabase(testDbPath)
-	ranking1 := Ranking{1114, 2, 18, 625, 90, 554, 10, 0.254, 3, 2, 1, 0, 10}
-	ranking2 := Ranking{254, 1, 20, 625, 90, 554, 10, 0.254, 1, 2, 3, 0, 10}
+	ranking1 := Ranking{1114, 2, 18, []int{625, 90, 554, 10}, 0.254, 3, 2, 1, 0, 10}
+	ranking2 := Ranking{254, 1, 20, []int{625, 90, 554, 10}, 0.254, 1, 2, 3, 0, 10}
 	db.CreateRanking(&ranking1)
 	db.CreateRanking(&ranking2)
 
@@ -32,8 +32,8 @@ func TestRankingsPdfReport(t *testing.T) {
 	defer clearDb()
 	db, _ = OpenDatabase(testDbPath)
 	eventSettings, _ = db.GetEventSettings()
-	ranking1 := Ranking{1114, 2, 18, 625, 90, 554, 10, 0.254, 3, 2, 1, 0, 10}
-	ranking2 := Ranking{254, 1, 20, 625, 90, 554, 10, 0.254, 3, 2, 1, 0, 10}
+	ranking1 := Ranking{1114, 2, 18, []int{625, 90, 554, 10}, 0.254, 3, 2, 1, 0, 10}
+	ranking2 := Ranking{254, 1, 20, []int{625, 90, 554, 10}, 0.254, 3, 2, 1, 0, 10}
 	db.CreateRanking(&ranking1)
 	db.CreateRanking(&ranking2)
 
@@ -43,6 +43,21 @@ func TestRankingsPdfReport(t *testing.T) {
 	assert.Equal(t, "application/pdf", recorder.HeaderMap["Content-Type"][0])
 }
 
+func TestBracketPdfReport(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	db, _ = OpenDatabase(testDbPath)
+	eventSettings, _ = db.GetEventSettings()
+	createTestAlliances(db, 4)
+	db.UpdateEliminationSchedule(time.Unix(0, 0))
+	scoreMatch(db, "SF1-1", "R")
+
+	// Can't really parse the PDF content and check it, so just check that what's sent back is a PDF.
+	recorder := getHttpResponse("/reports/pdf/bracket")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/pdf", recorder.HeaderMap["Content-Type"][0])
+}
+
 func TestScheduleCsvReport(t *testing.T) {
 	clearDb()
 	defer clearDb()
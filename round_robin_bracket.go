@@ -0,0 +1,251 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Playoff format in which the alliances play a round robin, with the top two advancing to a best-of-three final.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	defaultRoundRobinPointsRule = "wlt"
+	roundRobinRound             = 2
+	roundRobinWinPoints         = 2
+	roundRobinTiePoints         = 1
+)
+
+// All supported rules for awarding round-robin standings points, keyed by the identifier stored in the event
+// settings.
+var roundRobinPointsRules = map[string]string{"wlt": "2 points per win, 1 per tie",
+	"score": "Total match score"}
+
+// An alliance's record in the round-robin stage of the playoffs.
+type RoundRobinStanding struct {
+	AllianceId int
+	Played     int
+	Wins       int
+	Losses     int
+	Ties       int
+	TotalScore int
+	Points     int
+}
+
+type RoundRobinBracket struct{}
+
+func (bracket *RoundRobinBracket) Name() string {
+	return "Round Robin + Finals"
+}
+
+func (bracket *RoundRobinBracket) ValidateNumAlliances(numAlliances int) error {
+	if numAlliances < 3 || numAlliances > 8 {
+		return fmt.Errorf("Number of alliances must be between 3 and 8 for a round-robin playoff.")
+	}
+	return nil
+}
+
+func (bracket *RoundRobinBracket) MatchDisplayName(round int, group int, instance int) string {
+	if round == 1 {
+		return fmt.Sprintf("F-%d", instance)
+	}
+	return fmt.Sprintf("RR%d", group)
+}
+
+func (bracket *RoundRobinBracket) SeriesName(round int, group int) string {
+	if round == 1 {
+		return "F"
+	}
+	return fmt.Sprintf("RR%d", group)
+}
+
+func (bracket *RoundRobinBracket) NumWinsToAdvance(round int) int {
+	if round == 1 {
+		return 2
+	}
+	return 1
+}
+
+func (bracket *RoundRobinBracket) TbaCompLevel(round int) string {
+	if round == 1 {
+		return "f"
+	}
+	return "sf"
+}
+
+func (bracket *RoundRobinBracket) buildMatches(database *Database, numAlliances int) ([]AllianceTeam, error) {
+	if err := bracket.ValidateNumAlliances(numAlliances); err != nil {
+		return []AllianceTeam{}, err
+	}
+
+	// Create the full set of round-robin matches up front since all of the alliances are known.
+	for i, pairing := range roundRobinPairings(numAlliances) {
+		group := i + 1
+		matches, err := database.GetMatchesByElimRoundGroup(roundRobinRound, group)
+		if err != nil {
+			return []AllianceTeam{}, err
+		}
+		if len(matches) > 0 {
+			continue
+		}
+		redAlliance, err := database.GetTeamsByAlliance(pairing[0])
+		if err != nil {
+			return []AllianceTeam{}, err
+		}
+		blueAlliance, err := database.GetTeamsByAlliance(pairing[1])
+		if err != nil {
+			return []AllianceTeam{}, err
+		}
+		if len(redAlliance) < 3 || len(blueAlliance) < 3 {
+			return []AllianceTeam{}, fmt.Errorf("Alliances must consist of at least 3 teams")
+		}
+		err = database.CreateMatch(createMatch(bracket, roundRobinRound, group, 1, redAlliance, blueAlliance))
+		if err != nil {
+			return []AllianceTeam{}, err
+		}
+	}
+
+	// Wait until the round robin is over before seeding the finals.
+	standings, complete, err := database.CalculateRoundRobinStandings(numAlliances)
+	if err != nil || !complete {
+		return []AllianceTeam{}, err
+	}
+	redAlliance, err := database.GetTeamsByAlliance(standings[0].AllianceId)
+	if err != nil {
+		return []AllianceTeam{}, err
+	}
+	blueAlliance, err := database.GetTeamsByAlliance(standings[1].AllianceId)
+	if err != nil {
+		return []AllianceTeam{}, err
+	}
+	winner, _, err := database.updateEliminationMatchSet(bracket, 1, 1, bracket.NumWinsToAdvance(1), redAlliance,
+		blueAlliance)
+	return winner, err
+}
+
+// Tallies up the results of the round-robin matches played so far, returning the standings in order along with
+// whether all of the matches have been played.
+func (database *Database) CalculateRoundRobinStandings(numAlliances int) ([]RoundRobinStanding, bool, error) {
+	standings := make([]RoundRobinStanding, numAlliances)
+	for i := range standings {
+		standings[i].AllianceId = i + 1
+	}
+	complete := true
+	pairings := roundRobinPairings(numAlliances)
+	for i, pairing := range pairings {
+		matches, err := database.GetMatchesByElimRoundGroup(roundRobinRound, i+1)
+		if err != nil {
+			return nil, false, err
+		}
+		if len(matches) == 0 || matches[0].Status != "complete" {
+			complete = false
+			continue
+		}
+		match := matches[0]
+		red := &standings[pairing[0]-1]
+		blue := &standings[pairing[1]-1]
+		red.Played++
+		blue.Played++
+		switch match.Winner {
+		case "R":
+			red.Wins++
+			blue.Losses++
+		case "B":
+			blue.Wins++
+			red.Losses++
+		case "T":
+			red.Ties++
+			blue.Ties++
+		default:
+			return nil, false, fmt.Errorf("Completed match %d has invalid winner '%s'", match.Id, match.Winner)
+		}
+
+		matchResult, err := database.GetMatchResultForMatch(match.Id)
+		if err != nil {
+			return nil, false, err
+		}
+		if matchResult != nil {
+			red.TotalScore += matchResult.RedScoreSummary().Score
+			blue.TotalScore += matchResult.BlueScoreSummary().Score
+		}
+	}
+
+	pointsRule := defaultRoundRobinPointsRule
+	if eventSettings != nil {
+		if _, ok := roundRobinPointsRules[eventSettings.RoundRobinPointsRule]; ok {
+			pointsRule = eventSettings.RoundRobinPointsRule
+		}
+	}
+	for i := range standings {
+		if pointsRule == "score" {
+			standings[i].Points = standings[i].TotalScore
+		} else {
+			standings[i].Points = roundRobinWinPoints*standings[i].Wins + roundRobinTiePoints*standings[i].Ties
+		}
+	}
+	sort.Stable(byRoundRobinStanding(standings))
+
+	return standings, complete, nil
+}
+
+// Returns the pairs of alliance IDs that play each other in the round robin, in the order they should be played.
+// Uses the circle method so that alliances get a rest between matches where possible, and alternates which
+// alliance is on red to keep the sides balanced.
+func roundRobinPairings(numAlliances int) [][2]int {
+	alliances := make([]int, numAlliances)
+	for i := range alliances {
+		alliances[i] = i + 1
+	}
+	if numAlliances%2 == 1 {
+		// Add a placeholder for the alliance that sits out each round.
+		alliances = append(alliances, 0)
+	}
+
+	var pairings [][2]int
+	redCounts := make(map[int]int)
+	size := len(alliances)
+	for round := 0; round < size-1; round++ {
+		for i := 0; i < size/2; i++ {
+			red, blue := alliances[i], alliances[size-1-i]
+			if red == 0 || blue == 0 {
+				continue
+			}
+			if red > blue {
+				red, blue = blue, red
+			}
+			if redCounts[blue] < redCounts[red] {
+				red, blue = blue, red
+			}
+			redCounts[red]++
+			pairings = append(pairings, [2]int{red, blue})
+		}
+
+		// Rotate everyone except the first alliance one position clockwise.
+		last := alliances[size-1]
+		copy(alliances[2:], alliances[1:size-1])
+		alliances[1] = last
+	}
+	return pairings
+}
+
+// Orders round-robin standings by points, then total score, then alliance seed.
+type byRoundRobinStanding []RoundRobinStanding
+
+func (standings byRoundRobinStanding) Len() int {
+	return len(standings)
+}
+
+func (standings byRoundRobinStanding) Less(i, j int) bool {
+	if standings[i].Points != standings[j].Points {
+		return standings[i].Points > standings[j].Points
+	}
+	if standings[i].TotalScore != standings[j].TotalScore {
+		return standings[i].TotalScore > standings[j].TotalScore
+	}
+	return standings[i].AllianceId < standings[j].AllianceId
+}
+
+func (standings byRoundRobinStanding) Swap(i, j int) {
+	standings[i], standings[j] = standings[j], standings[i]
+}
@@ -0,0 +1,133 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package main
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRoundRobinPairings(t *testing.T) {
+	for numAlliances := 3; numAlliances <= 8; numAlliances++ {
+		pairings := roundRobinPairings(numAlliances)
+		assert.Equal(t, numAlliances*(numAlliances-1)/2, len(pairings))
+
+		// Every alliance should play every other alliance exactly once and be red about half the time.
+		seen := make(map[[2]int]bool)
+		redCounts := make(map[int]int)
+		for _, pairing := range pairings {
+			key := pairing
+			if key[0] > key[1] {
+				key[0], key[1] = key[1], key[0]
+			}
+			assert.False(t, seen[key])
+			seen[key] = true
+			redCounts[pairing[0]]++
+		}
+		for alliance := 1; alliance <= numAlliances; alliance++ {
+			assert.True(t, redCounts[alliance] >= (numAlliances-1)/2-1)
+			assert.True(t, redCounts[alliance] <= numAlliances/2+1)
+		}
+	}
+}
+
+func TestRoundRobinSchedule(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+	eventSettings.ElimType = "roundrobin"
+	defer func() { eventSettings.ElimType = defaultElimType }()
+
+	createTestAlliances(db, 2)
+	_, err = db.UpdateEliminationSchedule(time.Unix(0, 0))
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "must be between 3 and 8")
+	}
+	db.TruncateAllianceTeams()
+
+	createTestAlliances(db, 4)
+	_, err = db.UpdateEliminationSchedule(time.Unix(0, 0))
+	assert.Nil(t, err)
+	matches, _ := db.GetMatchesByType("elimination")
+	if assert.Equal(t, 6, len(matches)) {
+		for i, match := range matches {
+			assert.Equal(t, fmt.Sprintf("RR%d", i+1), match.DisplayName)
+		}
+		assertMatch(t, matches[0], "RR1", 1, 4)
+	}
+
+	// The finals shouldn't be created until the round robin is over. Alliance 3 wins all of its matches, and
+	// alliances 1 and 2 tie for second on points, with the tie broken by total score.
+	for i, pairing := range roundRobinPairings(4) {
+		match := matches[i]
+		winner := "R"
+		if pairing[0] == 3 || pairing[1] == 3 {
+			if pairing[1] == 3 {
+				winner = "B"
+			}
+		} else if pairing[0] == 4 {
+			winner = "B"
+		} else if pairing[1] != 4 {
+			winner = "T"
+		}
+		matchResult := NewMatchResult()
+		matchResult.MatchId = match.Id
+		if pairing[0] == 2 {
+			matchResult.RedScore.GameScore = StrongholdScore{AutoHighGoals: 1}
+		} else if pairing[1] == 2 {
+			matchResult.BlueScore.GameScore = StrongholdScore{AutoHighGoals: 1}
+		}
+		db.CreateMatchResult(matchResult)
+		match.Status = "complete"
+		match.Winner = winner
+		db.SaveMatch(&match)
+		if i < len(matches)-1 {
+			_, err = db.UpdateEliminationSchedule(time.Unix(0, 0))
+			assert.Nil(t, err)
+			updatedMatches, _ := db.GetMatchesByType("elimination")
+			assert.Equal(t, 6, len(updatedMatches))
+		}
+	}
+	standings, complete, err := db.CalculateRoundRobinStandings(4)
+	assert.Nil(t, err)
+	assert.True(t, complete)
+	if assert.Equal(t, 4, len(standings)) {
+		assert.Equal(t, RoundRobinStanding{3, 3, 3, 0, 0, 0, 6}, standings[0])
+		assert.Equal(t, RoundRobinStanding{2, 3, 1, 1, 1, 30, 3}, standings[1])
+		assert.Equal(t, RoundRobinStanding{1, 3, 1, 1, 1, 0, 3}, standings[2])
+		assert.Equal(t, RoundRobinStanding{4, 3, 0, 3, 0, 0, 0}, standings[3])
+	}
+
+	_, err = db.UpdateEliminationSchedule(time.Unix(0, 0))
+	assert.Nil(t, err)
+	matches, _ = db.GetMatchesByType("elimination")
+	if assert.Equal(t, 9, len(matches)) {
+		assertMatch(t, matches[6], "F-1", 3, 2)
+		assertMatch(t, matches[8], "F-3", 3, 2)
+	}
+	scoreMatch(db, "F-1", "B")
+	scoreMatch(db, "F-2", "B")
+	winner, err := db.UpdateEliminationSchedule(time.Unix(0, 0))
+	assert.Nil(t, err)
+	if assert.Equal(t, 3, len(winner)) {
+		assert.Equal(t, 2, winner[0].TeamId)
+	}
+
+	// Switching the points rule to total score should reorder the standings.
+	eventSettings.RoundRobinPointsRule = "score"
+	standings, _, _ = db.CalculateRoundRobinStandings(4)
+	assert.Equal(t, 2, standings[0].AllianceId)
+	eventSettings.RoundRobinPointsRule = defaultRoundRobinPointsRule
+
+	match, _ := db.GetMatchByName("elimination", "RR5")
+	assert.Equal(t, "sf5m1", match.TbaCode())
+	match, _ = db.GetMatchByName("elimination", "F-2")
+	assert.Equal(t, "f1m2", match.TbaCode())
+}
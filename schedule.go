@@ -18,15 +18,11 @@ import (
 const schedulesDir = "schedules"
 const teamsPerMatch = 6
 
-type ScheduleBlock struct {
-	StartTime       time.Time
-	NumMatches      int
-	MatchSpacingSec int
-}
-
-// Creates a random schedule for the given parameters and returns it as a list of matches.
-func BuildRandomSchedule(teams []Team, scheduleBlocks []ScheduleBlock, matchType string) ([]Match, error) {
-	// Load the anonymized, pre-randomized match schedule for the given number of teams and matches per team.
+// Creates a random schedule for the given parameters and returns it as a list of matches. Variant zero is the
+// standard schedule for the event size, starting from the pre-generated one if it exists; each other variant is an
+// alternative anonymized schedule generated from scratch, so that shuffles can be compared on more than team order.
+func BuildRandomSchedule(teams []Team, scheduleBlocks []ScheduleBlock, matchType string, variant int) ([]Match, error) {
+	// Build the anonymized, pre-randomized match schedule for the given number of teams and matches per team.
 	numTeams := len(teams)
 	numMatches := countMatches(scheduleBlocks)
 	matchesPerTeam := int(float32(numMatches*teamsPerMatch) / float32(numTeams))
@@ -34,30 +30,19 @@ func BuildRandomSchedule(teams []Team, scheduleBlocks []ScheduleBlock, matchType
 	// Adjust the number of matches to remove any excess from non-perfect block scheduling.
 	numMatches = int(math.Ceil(float64(numTeams) * float64(matchesPerTeam) / teamsPerMatch))
 
-	file, err := os.Open(fmt.Sprintf("%s/%d_%d.csv", schedulesDir, numTeams, matchesPerTeam))
-	if err != nil {
-		return nil, fmt.Errorf("No schedule template exists for %d teams and %d matches", numTeams, matchesPerTeam)
+	// Use the pre-generated schedule for this size as a starting point if one exists.
+	var seed [][12]int
+	var err error
+	if variant == 0 {
+		seed, err = loadScheduleTemplate(numTeams, matchesPerTeam, numMatches)
+		if err != nil {
+			return nil, err
+		}
 	}
-	defer file.Close()
-	reader := csv.NewReader(file)
-	csvLines, err := reader.ReadAll()
+	anonSchedule, err := generateAnonSchedule(numTeams, matchesPerTeam, numMatches, seed, variant)
 	if err != nil {
 		return nil, err
 	}
-	if len(csvLines) != numMatches {
-		return nil, fmt.Errorf("Schedule file contains %d matches, expected %d", len(csvLines), numMatches)
-	}
-
-	// Convert string fields from schedule to integers.
-	anonSchedule := make([][12]int, numMatches)
-	for i := 0; i < numMatches; i++ {
-		for j := 0; j < 12; j++ {
-			anonSchedule[i][j], err = strconv.Atoi(csvLines[i][j])
-			if err != nil {
-				return nil, err
-			}
-		}
-	}
 
 	// Generate a random permutation of the team ordering to fill into the pre-randomized schedule.
 	teamShuffle := rand.Perm(numTeams)
@@ -93,6 +78,38 @@ func BuildRandomSchedule(teams []Team, scheduleBlocks []ScheduleBlock, matchType
 	return matches, nil
 }
 
+// Loads the optional pre-generated anonymized schedule for the given parameters from the schedules directory.
+// Returns nil if no such schedule file exists.
+func loadScheduleTemplate(numTeams int, matchesPerTeam int, numMatches int) ([][12]int, error) {
+	file, err := os.Open(fmt.Sprintf("%s/%d_%d.csv", schedulesDir, numTeams, matchesPerTeam))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	reader := csv.NewReader(file)
+	csvLines, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(csvLines) != numMatches {
+		return nil, fmt.Errorf("Schedule file contains %d matches, expected %d", len(csvLines), numMatches)
+	}
+
+	// Convert string fields from schedule to integers.
+	anonSchedule := make([][12]int, numMatches)
+	for i := 0; i < numMatches; i++ {
+		for j := 0; j < 12; j++ {
+			anonSchedule[i][j], err = strconv.Atoi(csvLines[i][j])
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return anonSchedule, nil
+}
+
 // Returns the total number of matches that can be run within the given schedule blocks.
 func countMatches(scheduleBlocks []ScheduleBlock) int {
 	numMatches := 0
@@ -0,0 +1,114 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore CRUD methods for the blocks of time that a practice or qualification schedule is built from.
+
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+type ScheduleBlock struct {
+	Id              int
+	MatchType       string
+	StartTime       time.Time
+	NumMatches      int
+	MatchSpacingSec int
+}
+
+func (database *Database) CreateScheduleBlock(block *ScheduleBlock) error {
+	return database.scheduleBlockMap.Insert(block)
+}
+
+func (database *Database) GetScheduleBlockById(id int) (*ScheduleBlock, error) {
+	block := new(ScheduleBlock)
+	err := database.scheduleBlockMap.Get(block, id)
+	if err != nil && err.Error() == "sql: no rows in result set" {
+		block = nil
+		err = nil
+	}
+	return block, err
+}
+
+func (database *Database) SaveScheduleBlock(block *ScheduleBlock) error {
+	_, err := database.scheduleBlockMap.Update(block)
+	return err
+}
+
+func (database *Database) GetScheduleBlocksByMatchType(matchType string) ([]ScheduleBlock, error) {
+	var blocks []ScheduleBlock
+	err := database.scheduleBlockMap.Select(&blocks, "SELECT * FROM schedule_blocks WHERE matchtype = ? ORDER BY id",
+		matchType)
+	return blocks, err
+}
+
+func (database *Database) DeleteScheduleBlocksByMatchType(matchType string) error {
+	_, err := database.scheduleBlockMap.Exec("DELETE FROM schedule_blocks WHERE matchtype = ?", matchType)
+	return err
+}
+
+func (database *Database) TruncateScheduleBlocks() error {
+	return database.scheduleBlockMap.TruncateTables()
+}
+
+// Replaces the stored schedule blocks for the given match type with the given ones.
+func (database *Database) SaveScheduleBlocks(matchType string, blocks []ScheduleBlock) error {
+	err := database.DeleteScheduleBlocksByMatchType(matchType)
+	if err != nil {
+		return err
+	}
+	for _, block := range blocks {
+		block.Id = 0
+		block.MatchType = matchType
+		err = database.CreateScheduleBlock(&block)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Moves the given stored schedule block to the new start time and re-times all of its unplayed matches to match,
+// keeping the same match spacing and team assignments.
+func (database *Database) ShiftScheduleBlock(blockId int, startTime time.Time) error {
+	block, err := database.GetScheduleBlockById(blockId)
+	if err != nil {
+		return err
+	}
+	if block == nil {
+		return fmt.Errorf("Error: No such schedule block: %d", blockId)
+	}
+	blocks, err := database.GetScheduleBlocksByMatchType(block.MatchType)
+	if err != nil {
+		return err
+	}
+	matches, err := database.GetMatchesByType(block.MatchType)
+	if err != nil {
+		return err
+	}
+
+	// Blocks are filled in order, so the matches belonging to this block follow those of all the preceding ones.
+	firstMatchIndex := 0
+	for _, otherBlock := range blocks {
+		if otherBlock.Id == block.Id {
+			break
+		}
+		firstMatchIndex += otherBlock.NumMatches
+	}
+	for i := 0; i < block.NumMatches && firstMatchIndex+i < len(matches); i++ {
+		match := matches[firstMatchIndex+i]
+		if match.Status == "complete" {
+			continue
+		}
+		match.Time = startTime.Add(time.Duration(i*block.MatchSpacingSec) * time.Second)
+		err = database.SaveMatch(&match)
+		if err != nil {
+			return err
+		}
+	}
+
+	block.StartTime = startTime
+	return database.SaveScheduleBlock(block)
+}
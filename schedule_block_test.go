@@ -0,0 +1,89 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestScheduleBlockCrud(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	db, err := OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+
+	blocks := []ScheduleBlock{{0, "", time.Unix(1000, 0).UTC(), 10, 360}, {0, "", time.Unix(9000, 0).UTC(), 5, 420}}
+	assert.Nil(t, db.SaveScheduleBlocks("qualification", blocks))
+	db.CreateScheduleBlock(&ScheduleBlock{0, "practice", time.Unix(500, 0).UTC(), 4, 300})
+	qualBlocks, err := db.GetScheduleBlocksByMatchType("qualification")
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(qualBlocks)) {
+		assert.Equal(t, "qualification", qualBlocks[0].MatchType)
+		assert.Equal(t, time.Unix(1000, 0).UTC(), qualBlocks[0].StartTime.UTC())
+		assert.Equal(t, 5, qualBlocks[1].NumMatches)
+		assert.Equal(t, 420, qualBlocks[1].MatchSpacingSec)
+	}
+
+	// Saving the blocks again should replace the existing ones for that match type only.
+	assert.Nil(t, db.SaveScheduleBlocks("qualification", blocks[1:]))
+	qualBlocks, _ = db.GetScheduleBlocksByMatchType("qualification")
+	assert.Equal(t, 1, len(qualBlocks))
+	practiceBlocks, _ := db.GetScheduleBlocksByMatchType("practice")
+	assert.Equal(t, 1, len(practiceBlocks))
+
+	block, err := db.GetScheduleBlockById(qualBlocks[0].Id)
+	assert.Nil(t, err)
+	assert.Equal(t, qualBlocks[0].NumMatches, block.NumMatches)
+	block, err = db.GetScheduleBlockById(1254)
+	assert.Nil(t, err)
+	assert.Nil(t, block)
+
+	db.TruncateScheduleBlocks()
+	practiceBlocks, _ = db.GetScheduleBlocksByMatchType("practice")
+	assert.Empty(t, practiceBlocks)
+}
+
+func TestShiftScheduleBlock(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	db, err := OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+
+	blocks := []ScheduleBlock{{0, "", time.Unix(1000, 0).UTC(), 2, 100}, {0, "", time.Unix(5000, 0).UTC(), 3, 200}}
+	assert.Nil(t, db.SaveScheduleBlocks("qualification", blocks))
+	for i := 0; i < 5; i++ {
+		match := Match{Type: "qualification", DisplayName: strconv.Itoa(i + 1), Red1: 101 + i}
+		if i < 2 {
+			match.Time = time.Unix(int64(1000+i*100), 0).UTC()
+		} else {
+			match.Time = time.Unix(int64(5000+(i-2)*200), 0).UTC()
+		}
+		if i == 2 {
+			match.Status = "complete"
+		}
+		db.CreateMatch(&match)
+	}
+
+	savedBlocks, _ := db.GetScheduleBlocksByMatchType("qualification")
+	assert.Nil(t, db.ShiftScheduleBlock(savedBlocks[1].Id, time.Unix(6000, 0).UTC()))
+	matches, _ := db.GetMatchesByType("qualification")
+	assert.Equal(t, int64(1000), matches[0].Time.Unix())
+	assert.Equal(t, int64(1100), matches[1].Time.Unix())
+	assert.Equal(t, int64(5000), matches[2].Time.Unix()) // Already played, so it shouldn't move.
+	assert.Equal(t, int64(6200), matches[3].Time.Unix())
+	assert.Equal(t, int64(6400), matches[4].Time.Unix())
+	assert.Equal(t, 104, matches[3].Red1)
+	block, _ := db.GetScheduleBlockById(savedBlocks[1].Id)
+	assert.Equal(t, int64(6000), block.StartTime.Unix())
+
+	err = db.ShiftScheduleBlock(1254, time.Unix(6000, 0).UTC())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "No such schedule block")
+	}
+}
@@ -0,0 +1,356 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// In-process generator for anonymized qualification schedules of any size, using simulated annealing to optimize
+// round spacing, partner/opponent repetition, red/blue balance and surrogate placement.
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Weights of the various criteria that are traded off against each other when optimizing a schedule.
+const (
+	scheduleDuplicateTeamCost  = 1000
+	scheduleSpacingCost        = 20
+	schedulePartnerRepeatCost  = 10
+	scheduleOpponentRepeatCost = 3
+	scheduleColorBalanceCost   = 5
+	scheduleSurrogateCost      = 50
+)
+
+const (
+	scheduleIterationsPerMatch = 2000
+	scheduleMaxIterations      = 500000
+	scheduleInitialTemperature = 20.0
+	scheduleFinalTemperature   = 0.1
+)
+
+// Holds the state of a schedule being optimized, along with the running tallies needed to cheaply evaluate the
+// effect of swapping two teams.
+type scheduleGenerator struct {
+	numTeams       int
+	matchesPerTeam int
+	minSpacing     int
+	teams          [][teamsPerMatch]int
+	surrogates     [][teamsPerMatch]bool
+	teamMatches    [][]int
+	surrogateCount []int
+	partnerCounts  []int
+	opponentCounts []int
+	redCounts      []int
+	blueCounts     []int
+	cost           int
+	rand           *rand.Rand
+}
+
+// Returns an anonymized schedule in the same format as the schedule CSV files, with each match consisting of six
+// pairs of one-indexed team number and surrogate flag. If a seed schedule is given, it is used as the starting point
+// and only improved upon; otherwise a new schedule is built from scratch. Different variants of the same size yield
+// different schedules.
+func generateAnonSchedule(numTeams int, matchesPerTeam int, numMatches int, seed [][12]int,
+	variant int) ([][12]int, error) {
+	if numTeams < teamsPerMatch {
+		return nil, fmt.Errorf("Must have at least %d teams to generate a schedule", teamsPerMatch)
+	}
+	if matchesPerTeam < 1 {
+		return nil, fmt.Errorf("Must have at least 1 match per team to generate a schedule")
+	}
+
+	// Seed the generator deterministically so that, like the schedule files, the anonymized schedule for a given
+	// size and variant is always the same and only the assignment of teams to it is random.
+	generator := &scheduleGenerator{numTeams: numTeams, matchesPerTeam: matchesPerTeam,
+		rand: rand.New(rand.NewSource(int64(variant)<<32 + int64(numTeams*1000+matchesPerTeam)))}
+	generator.minSpacing = (numMatches/matchesPerTeam + 1) / 2
+	if seed != nil {
+		err := generator.loadSeed(seed)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		generator.buildInitialSchedule(numMatches)
+	}
+	generator.initializeTallies()
+
+	iterations := numMatches * scheduleIterationsPerMatch
+	if iterations > scheduleMaxIterations {
+		iterations = scheduleMaxIterations
+	}
+	if seed != nil {
+		generator.optimize(iterations, 0)
+	} else {
+		generator.optimize(iterations, scheduleInitialTemperature)
+	}
+	if generator.hasDuplicateTeams() {
+		return nil, fmt.Errorf("Unable to generate a schedule for %d teams and %d matches without a team "+
+			"appearing twice in the same match", numTeams, matchesPerTeam)
+	}
+
+	anonSchedule := make([][12]int, numMatches)
+	for i := range generator.teams {
+		for j := 0; j < teamsPerMatch; j++ {
+			anonSchedule[i][2*j] = generator.teams[i][j] + 1
+			if generator.surrogates[i][j] {
+				anonSchedule[i][2*j+1] = 1
+			}
+		}
+	}
+	return anonSchedule, nil
+}
+
+// Populates the schedule from a pre-generated one, validating that it only refers to teams that exist.
+func (generator *scheduleGenerator) loadSeed(seed [][12]int) error {
+	generator.teams = make([][teamsPerMatch]int, len(seed))
+	generator.surrogates = make([][teamsPerMatch]bool, len(seed))
+	for i, seedMatch := range seed {
+		for j := 0; j < teamsPerMatch; j++ {
+			team := seedMatch[2*j]
+			if team < 1 || team > generator.numTeams {
+				return fmt.Errorf("Schedule file contains invalid team %d in match %d", team, i+1)
+			}
+			generator.teams[i][j] = team - 1
+			generator.surrogates[i][j] = seedMatch[2*j+1] == 1
+		}
+	}
+	return nil
+}
+
+// Fills the schedule with successive random permutations of the teams, one per round. Any slots left over when the
+// number of appearances isn't a multiple of the match size are filled by surrogates placed in the third round.
+func (generator *scheduleGenerator) buildInitialSchedule(numMatches int) {
+	numSurrogates := numMatches*teamsPerMatch - generator.numTeams*generator.matchesPerTeam
+	surrogateRound := 2
+	if surrogateRound >= generator.matchesPerTeam {
+		surrogateRound = generator.matchesPerTeam - 1
+	}
+
+	var slots []int
+	var surrogateSlots []bool
+	for round := 0; round < generator.matchesPerTeam; round++ {
+		if round == surrogateRound {
+			for _, team := range generator.rand.Perm(generator.numTeams)[:numSurrogates] {
+				slots = append(slots, team)
+				surrogateSlots = append(surrogateSlots, true)
+			}
+		}
+		for _, team := range generator.rand.Perm(generator.numTeams) {
+			slots = append(slots, team)
+			surrogateSlots = append(surrogateSlots, false)
+		}
+	}
+
+	generator.teams = make([][teamsPerMatch]int, numMatches)
+	generator.surrogates = make([][teamsPerMatch]bool, numMatches)
+	for i := range slots {
+		generator.teams[i/teamsPerMatch][i%teamsPerMatch] = slots[i]
+		generator.surrogates[i/teamsPerMatch][i%teamsPerMatch] = surrogateSlots[i]
+	}
+}
+
+// Computes the per-team and per-pair tallies and the total cost of the schedule from scratch.
+func (generator *scheduleGenerator) initializeTallies() {
+	numTeams := generator.numTeams
+	generator.teamMatches = make([][]int, numTeams)
+	generator.surrogateCount = make([]int, numTeams)
+	generator.partnerCounts = make([]int, numTeams*numTeams)
+	generator.opponentCounts = make([]int, numTeams*numTeams)
+	generator.redCounts = make([]int, numTeams)
+	generator.blueCounts = make([]int, numTeams)
+	generator.cost = 0
+	for i, match := range generator.teams {
+		for j, team := range match {
+			generator.teamMatches[team] = append(generator.teamMatches[team], i)
+			if generator.surrogates[i][j] {
+				generator.surrogateCount[team]++
+			}
+		}
+		generator.cost += generator.updateMatchTallies(i, 1) + generator.matchCost(i)
+	}
+	for team := 0; team < numTeams; team++ {
+		generator.cost += generator.teamCost(team)
+	}
+}
+
+// Runs simulated annealing for the given number of iterations, randomly swapping teams between matches. A starting
+// temperature of zero only accepts swaps that improve the schedule.
+func (generator *scheduleGenerator) optimize(iterations int, initialTemperature float64) {
+	numMatches := len(generator.teams)
+	if numMatches < 2 {
+		return
+	}
+	for i := 0; i < iterations; i++ {
+		match1 := generator.rand.Intn(numMatches)
+		match2 := generator.rand.Intn(numMatches)
+		slot1 := generator.rand.Intn(teamsPerMatch)
+		slot2 := generator.rand.Intn(teamsPerMatch)
+		if match1 == match2 || generator.teams[match1][slot1] == generator.teams[match2][slot2] {
+			continue
+		}
+
+		delta := generator.swap(match1, slot1, match2, slot2)
+		accept := delta < 0
+		if !accept && initialTemperature > 0 {
+			temperature := initialTemperature *
+				math.Pow(scheduleFinalTemperature/initialTemperature, float64(i)/float64(iterations))
+			accept = generator.rand.Float64() < math.Exp(-float64(delta)/temperature)
+		}
+		if accept {
+			generator.cost += delta
+		} else {
+			generator.swap(match1, slot1, match2, slot2)
+		}
+	}
+}
+
+// Exchanges the teams (and their surrogate status) in the two given slots, updating the tallies and returning the
+// resulting change in the cost of the schedule.
+func (generator *scheduleGenerator) swap(match1 int, slot1 int, match2 int, slot2 int) int {
+	team1 := generator.teams[match1][slot1]
+	team2 := generator.teams[match2][slot2]
+	before := generator.matchCost(match1) + generator.matchCost(match2) + generator.teamCost(team1) +
+		generator.teamCost(team2)
+	delta := generator.updateMatchTallies(match1, -1) + generator.updateMatchTallies(match2, -1)
+
+	generator.teams[match1][slot1], generator.teams[match2][slot2] = team2, team1
+	generator.surrogates[match1][slot1], generator.surrogates[match2][slot2] =
+		generator.surrogates[match2][slot2], generator.surrogates[match1][slot1]
+	generator.moveTeamMatch(team1, match1, match2)
+	generator.moveTeamMatch(team2, match2, match1)
+
+	delta += generator.updateMatchTallies(match1, 1) + generator.updateMatchTallies(match2, 1)
+	after := generator.matchCost(match1) + generator.matchCost(match2) + generator.teamCost(team1) +
+		generator.teamCost(team2)
+	return delta + after - before
+}
+
+// Replaces one of the given team's appearances in the old match with one in the new match, keeping the list of the
+// team's matches sorted.
+func (generator *scheduleGenerator) moveTeamMatch(team int, oldMatch int, newMatch int) {
+	matches := generator.teamMatches[team]
+	i := 0
+	for matches[i] != oldMatch {
+		i++
+	}
+	for i > 0 && matches[i-1] > newMatch {
+		matches[i] = matches[i-1]
+		i--
+	}
+	for i < len(matches)-1 && matches[i+1] < newMatch {
+		matches[i] = matches[i+1]
+		i++
+	}
+	matches[i] = newMatch
+}
+
+// Adds (direction 1) or removes (direction -1) the given match's contribution to the partner, opponent and color
+// tallies, returning the resulting change in the repetition cost.
+func (generator *scheduleGenerator) updateMatchTallies(match int, direction int) int {
+	teams := generator.teams[match]
+	delta := 0
+	for i := 0; i < teamsPerMatch; i++ {
+		if i < teamsPerMatch/2 {
+			generator.redCounts[teams[i]] += direction
+		} else {
+			generator.blueCounts[teams[i]] += direction
+		}
+		for j := i + 1; j < teamsPerMatch; j++ {
+			if teams[i] == teams[j] {
+				continue
+			}
+			index := teams[i]*generator.numTeams + teams[j]
+			if teams[i] > teams[j] {
+				index = teams[j]*generator.numTeams + teams[i]
+			}
+			if (i < teamsPerMatch/2) == (j < teamsPerMatch/2) {
+				delta += updateRepeatTally(&generator.partnerCounts[index], direction, schedulePartnerRepeatCost)
+			} else {
+				delta += updateRepeatTally(&generator.opponentCounts[index], direction, scheduleOpponentRepeatCost)
+			}
+		}
+	}
+	return delta
+}
+
+// Increments or decrements the given pair tally and returns the change in its cost, which grows quadratically
+// with the number of repeat meetings.
+func updateRepeatTally(count *int, direction int, weight int) int {
+	before := repeatCost(*count, weight)
+	*count += direction
+	return repeatCost(*count, weight) - before
+}
+
+func repeatCost(count int, weight int) int {
+	if count <= 1 {
+		return 0
+	}
+	return weight * (count - 1) * (count - 1)
+}
+
+// Returns the cost of any team appearing more than once within the given match.
+func (generator *scheduleGenerator) matchCost(match int) int {
+	cost := 0
+	teams := generator.teams[match]
+	for i := 0; i < teamsPerMatch; i++ {
+		for j := i + 1; j < teamsPerMatch; j++ {
+			if teams[i] == teams[j] {
+				cost += scheduleDuplicateTeamCost
+			}
+		}
+	}
+	return cost
+}
+
+// Returns the cost of the given team's round spacing, red/blue imbalance and surrogate placement.
+func (generator *scheduleGenerator) teamCost(team int) int {
+	cost := 0
+	matches := generator.teamMatches[team]
+	for i := 1; i < len(matches); i++ {
+		if gap := matches[i] - matches[i-1]; gap < generator.minSpacing {
+			cost += scheduleSpacingCost * (generator.minSpacing - gap) * (generator.minSpacing - gap)
+		}
+	}
+
+	imbalance := generator.redCounts[team] - generator.blueCounts[team]
+	if imbalance < 0 {
+		imbalance = -imbalance
+	}
+	if imbalance > 1 {
+		cost += scheduleColorBalanceCost * (imbalance - 1) * (imbalance - 1)
+	}
+
+	// A surrogate appearance should be the team's third match so that it is neither the first nor the last.
+	if generator.surrogateCount[team] == 0 {
+		return cost
+	}
+	surrogateIndex := 2
+	if surrogateIndex >= len(matches) {
+		surrogateIndex = len(matches) - 1
+	}
+	for i, match := range matches {
+		if i != surrogateIndex && generator.isSurrogate(match, team) {
+			cost += scheduleSurrogateCost
+		}
+	}
+	return cost
+}
+
+func (generator *scheduleGenerator) isSurrogate(match int, team int) bool {
+	for i, matchTeam := range generator.teams[match] {
+		if matchTeam == team && generator.surrogates[match][i] {
+			return true
+		}
+	}
+	return false
+}
+
+func (generator *scheduleGenerator) hasDuplicateTeams() bool {
+	for i := range generator.teams {
+		if generator.matchCost(i) > 0 {
+			return true
+		}
+	}
+	return false
+}
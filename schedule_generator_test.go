@@ -0,0 +1,102 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestGenerateAnonScheduleErrors(t *testing.T) {
+	_, err := generateAnonSchedule(5, 2, 2, nil, 0)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "Must have at least 6 teams to generate a schedule", err.Error())
+	}
+	_, err = generateAnonSchedule(18, 0, 0, nil, 0)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "Must have at least 1 match per team to generate a schedule", err.Error())
+	}
+	_, err = generateAnonSchedule(6, 1, 1, [][12]int{{1, 0, 2, 0, 3, 0, 4, 0, 5, 0, 7, 0}}, 0)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "Schedule file contains invalid team 7 in match 1", err.Error())
+	}
+}
+
+func TestGenerateAnonSchedule(t *testing.T) {
+	for _, size := range [][2]int{{6, 2}, {18, 6}, {25, 9}, {38, 10}, {101, 12}} {
+		numTeams, matchesPerTeam := size[0], size[1]
+		numMatches := int(math.Ceil(float64(numTeams*matchesPerTeam) / teamsPerMatch))
+		anonSchedule, err := generateAnonSchedule(numTeams, matchesPerTeam, numMatches, nil, 0)
+		assert.Nil(t, err)
+		assertScheduleIsValid(t, anonSchedule, numTeams, matchesPerTeam)
+	}
+
+	// The anonymized schedule should be the same each time for a given size and variant.
+	anonSchedule1, _ := generateAnonSchedule(25, 9, 38, nil, 0)
+	anonSchedule2, _ := generateAnonSchedule(25, 9, 38, nil, 0)
+	assert.Equal(t, anonSchedule1, anonSchedule2)
+	anonSchedule3, err := generateAnonSchedule(25, 9, 38, nil, 1)
+	assert.Nil(t, err)
+	assertScheduleIsValid(t, anonSchedule3, 25, 9)
+	assert.NotEqual(t, anonSchedule1, anonSchedule3)
+}
+
+func TestGenerateAnonScheduleFromSeed(t *testing.T) {
+	seed, err := loadScheduleTemplate(38, 10, 64)
+	assert.Nil(t, err)
+	anonSchedule, err := generateAnonSchedule(38, 10, 64, seed, 0)
+	assert.Nil(t, err)
+	assertScheduleIsValid(t, anonSchedule, 38, 10)
+
+	seed, err = loadScheduleTemplate(1254, 10, 64)
+	assert.Nil(t, err)
+	assert.Nil(t, seed)
+}
+
+// Checks the hard constraints on a generated schedule along with reasonable bounds on its quality.
+func assertScheduleIsValid(t *testing.T, anonSchedule [][12]int, numTeams int, matchesPerTeam int) {
+	numMatches := len(anonSchedule)
+	matchCounts := make([]int, numTeams+1)
+	surrogateCounts := make([]int, numTeams+1)
+	redCounts := make([]int, numTeams+1)
+	lastMatches := make([]int, numTeams+1)
+	for i := range lastMatches {
+		lastMatches[i] = -numMatches
+	}
+	minSpacing := numMatches
+	for i, match := range anonSchedule {
+		teamsInMatch := make(map[int]bool)
+		for j := 0; j < teamsPerMatch; j++ {
+			team := match[2*j]
+			assert.False(t, teamsInMatch[team], "Team %d appears twice in match %d", team, i+1)
+			teamsInMatch[team] = true
+			if match[2*j+1] == 1 {
+				surrogateCounts[team]++
+			} else {
+				matchCounts[team]++
+			}
+			if j < teamsPerMatch/2 {
+				redCounts[team]++
+			}
+			if lastMatches[team] >= 0 && i-lastMatches[team] < minSpacing {
+				minSpacing = i - lastMatches[team]
+			}
+			lastMatches[team] = i
+		}
+	}
+
+	numSurrogates := 0
+	for team := 1; team <= numTeams; team++ {
+		assert.Equal(t, matchesPerTeam, matchCounts[team], "Team %d plays the wrong number of matches", team)
+		assert.True(t, surrogateCounts[team] <= 1)
+		numSurrogates += surrogateCounts[team]
+		blueCount := matchCounts[team] + surrogateCounts[team] - redCounts[team]
+		assert.True(t, math.Abs(float64(redCounts[team]-blueCount)) <= 2, "Team %d has unbalanced colors", team)
+	}
+	assert.Equal(t, numMatches*teamsPerMatch-numTeams*matchesPerTeam, numSurrogates)
+	if numMatches >= 2*matchesPerTeam {
+		assert.True(t, minSpacing >= 2, "Schedule has back-to-back matches for %d teams", numTeams)
+	}
+}
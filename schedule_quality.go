@@ -0,0 +1,266 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Functions and web handlers for evaluating how fair a practice or qualification match schedule is.
+
+package main
+
+import (
+	"fmt"
+	"github.com/gorilla/mux"
+	"html/template"
+	"net/http"
+	"sort"
+	"time"
+)
+
+var stationNames = []string{"R1", "R2", "R3", "B1", "B2", "B3"}
+
+// Summary of the fairness of a schedule as a whole. A lower score indicates a better schedule.
+type ScheduleQuality struct {
+	NumMatches          int
+	Score               int
+	MinTurnaround       int
+	MinTurnaroundTime   time.Duration
+	PartnerDuplicates   int
+	OpponentDuplicates  int
+	MaxPartnerCount     int
+	MaxOpponentCount    int
+	MaxColorImbalance   int
+	MisplacedSurrogates int
+	Teams               []TeamScheduleQuality
+	Surrogates          []ScheduleSurrogate
+}
+
+// Per-team breakdown of the schedule fairness metrics.
+type TeamScheduleQuality struct {
+	TeamId             int
+	NumMatches         int
+	MinTurnaround      int
+	MinTurnaroundTime  time.Duration
+	PartnerDuplicates  int
+	OpponentDuplicates int
+	RedCount           int
+	BlueCount          int
+	StationCounts      [teamsPerMatch]int
+}
+
+// Location of a surrogate appearance within the schedule.
+type ScheduleSurrogate struct {
+	TeamId           int
+	MatchDisplayName string
+	Station          string
+	TeamMatchNumber  int
+	NumTeamMatches   int
+}
+
+// Calculates the fairness metrics for the given schedule, which is assumed to be in the order it will be played.
+// Turnarounds are expressed as the difference in match number between a team's consecutive matches.
+func EvaluateSchedule(matches []Match) *ScheduleQuality {
+	quality := ScheduleQuality{NumMatches: len(matches)}
+	teamQualities := make(map[int]*TeamScheduleQuality)
+	teamMatchIndices := make(map[int][]int)
+	partnerCounts := make(map[[2]int]int)
+	opponentCounts := make(map[[2]int]int)
+	var surrogates []ScheduleSurrogate
+
+	for i, match := range matches {
+		teams := [teamsPerMatch]int{match.Red1, match.Red2, match.Red3, match.Blue1, match.Blue2, match.Blue3}
+		isSurrogate := [teamsPerMatch]bool{match.Red1IsSurrogate, match.Red2IsSurrogate, match.Red3IsSurrogate,
+			match.Blue1IsSurrogate, match.Blue2IsSurrogate, match.Blue3IsSurrogate}
+		for j, team := range teams {
+			if team == 0 {
+				continue
+			}
+			teamQuality, ok := teamQualities[team]
+			if !ok {
+				teamQuality = &TeamScheduleQuality{TeamId: team}
+				teamQualities[team] = teamQuality
+			}
+			teamQuality.NumMatches++
+			teamQuality.StationCounts[j]++
+			if j < teamsPerMatch/2 {
+				teamQuality.RedCount++
+			} else {
+				teamQuality.BlueCount++
+			}
+			teamMatchIndices[team] = append(teamMatchIndices[team], i)
+			if isSurrogate[j] {
+				surrogates = append(surrogates, ScheduleSurrogate{TeamId: team, MatchDisplayName: match.DisplayName,
+					Station: stationNames[j], TeamMatchNumber: teamQuality.NumMatches})
+			}
+
+			for k := j + 1; k < teamsPerMatch; k++ {
+				if teams[k] == 0 {
+					continue
+				}
+				pair := [2]int{team, teams[k]}
+				if pair[0] > pair[1] {
+					pair[0], pair[1] = pair[1], pair[0]
+				}
+				if (j < teamsPerMatch/2) == (k < teamsPerMatch/2) {
+					partnerCounts[pair]++
+				} else {
+					opponentCounts[pair]++
+				}
+			}
+		}
+	}
+	if len(teamQualities) == 0 {
+		return &quality
+	}
+
+	// Tally the repeat partners and opponents for each team and the schedule as a whole.
+	for pair, count := range partnerCounts {
+		if count > quality.MaxPartnerCount {
+			quality.MaxPartnerCount = count
+		}
+		if count > 1 {
+			quality.PartnerDuplicates += count - 1
+			teamQualities[pair[0]].PartnerDuplicates += count - 1
+			teamQualities[pair[1]].PartnerDuplicates += count - 1
+		}
+		quality.Score += repeatCost(count, schedulePartnerRepeatCost)
+	}
+	for pair, count := range opponentCounts {
+		if count > quality.MaxOpponentCount {
+			quality.MaxOpponentCount = count
+		}
+		if count > 1 {
+			quality.OpponentDuplicates += count - 1
+			teamQualities[pair[0]].OpponentDuplicates += count - 1
+			teamQualities[pair[1]].OpponentDuplicates += count - 1
+		}
+		quality.Score += repeatCost(count, scheduleOpponentRepeatCost)
+	}
+
+	// Work out each team's turnarounds and red/blue balance, penalizing them in the same way as the generator.
+	quality.MinTurnaround = len(matches)
+	quality.MinTurnaroundTime = -1
+	numAppearances := 0
+	for _, teamQuality := range teamQualities {
+		numAppearances += teamQuality.NumMatches
+	}
+	averageMatchesPerTeam := numAppearances / len(teamQualities)
+	minSpacing := 0
+	if averageMatchesPerTeam > 0 {
+		minSpacing = (len(matches)/averageMatchesPerTeam + 1) / 2
+	}
+	for team, teamQuality := range teamQualities {
+		indices := teamMatchIndices[team]
+		for i := 1; i < len(indices); i++ {
+			gap := indices[i] - indices[i-1]
+			gapTime := matches[indices[i]].Time.Sub(matches[indices[i-1]].Time)
+			if i == 1 || gap < teamQuality.MinTurnaround {
+				teamQuality.MinTurnaround = gap
+			}
+			if i == 1 || gapTime < teamQuality.MinTurnaroundTime {
+				teamQuality.MinTurnaroundTime = gapTime
+			}
+			if gap < minSpacing {
+				quality.Score += scheduleSpacingCost * (minSpacing - gap) * (minSpacing - gap)
+			}
+		}
+		if len(indices) > 1 {
+			if teamQuality.MinTurnaround < quality.MinTurnaround {
+				quality.MinTurnaround = teamQuality.MinTurnaround
+			}
+			if quality.MinTurnaroundTime == -1 || teamQuality.MinTurnaroundTime < quality.MinTurnaroundTime {
+				quality.MinTurnaroundTime = teamQuality.MinTurnaroundTime
+			}
+		}
+
+		imbalance := teamQuality.RedCount - teamQuality.BlueCount
+		if imbalance < 0 {
+			imbalance = -imbalance
+		}
+		if imbalance > quality.MaxColorImbalance {
+			quality.MaxColorImbalance = imbalance
+		}
+		if imbalance > 1 {
+			quality.Score += scheduleColorBalanceCost * (imbalance - 1) * (imbalance - 1)
+		}
+
+		quality.Teams = append(quality.Teams, *teamQuality)
+	}
+	if quality.MinTurnaround == len(matches) {
+		quality.MinTurnaround = 0
+	}
+	if quality.MinTurnaroundTime == -1 {
+		quality.MinTurnaroundTime = 0
+	}
+	sort.Sort(byTeamId(quality.Teams))
+
+	// A surrogate appearance should be the team's third match so that it is neither the first nor the last.
+	for i := range surrogates {
+		surrogates[i].NumTeamMatches = teamQualities[surrogates[i].TeamId].NumMatches
+		surrogateIndex := 3
+		if surrogateIndex > surrogates[i].NumTeamMatches {
+			surrogateIndex = surrogates[i].NumTeamMatches
+		}
+		if surrogates[i].TeamMatchNumber != surrogateIndex {
+			quality.MisplacedSurrogates++
+			quality.Score += scheduleSurrogateCost
+		}
+	}
+	quality.Surrogates = surrogates
+
+	return &quality
+}
+
+type byTeamId []TeamScheduleQuality
+
+func (teams byTeamId) Len() int {
+	return len(teams)
+}
+
+func (teams byTeamId) Less(i, j int) bool {
+	return teams[i].TeamId < teams[j].TeamId
+}
+
+func (teams byTeamId) Swap(i, j int) {
+	teams[i], teams[j] = teams[j], teams[i]
+}
+
+// Shows the fairness report for the saved schedule of the given type.
+func ScheduleQualityReportHandler(w http.ResponseWriter, r *http.Request) {
+	if !UserIsReader(w, r) {
+		return
+	}
+
+	vars := mux.Vars(r)
+	matches, err := db.GetMatchesByType(vars["type"])
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	renderScheduleQuality(w, r, fmt.Sprintf("Saved %s schedule", vars["type"]), matches)
+}
+
+// Shows the fairness report for the schedule that has been generated but not yet saved.
+func ScheduleGeneratedQualityHandler(w http.ResponseWriter, r *http.Request) {
+	if !UserIsAdmin(w, r) {
+		return
+	}
+
+	renderScheduleQuality(w, r, fmt.Sprintf("Generated %s schedule (not yet saved)", cachedMatchType), cachedMatches)
+}
+
+func renderScheduleQuality(w http.ResponseWriter, r *http.Request, description string, matches []Match) {
+	template, err := template.ParseFiles("templates/schedule_quality.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*EventSettings
+		Description  string
+		Quality      *ScheduleQuality
+		StationNames []string
+	}{eventSettings, description, EvaluateSchedule(matches), stationNames}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
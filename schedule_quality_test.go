@@ -0,0 +1,85 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestEvaluateSchedule(t *testing.T) {
+	startTime := time.Unix(1000, 0)
+	matches := []Match{
+		{DisplayName: "1", Time: startTime, Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5, Blue3: 6},
+		{DisplayName: "2", Time: startTime.Add(6 * time.Minute), Red1: 7, Red2: 8, Red3: 9, Blue1: 10, Blue2: 11,
+			Blue3: 12},
+		{DisplayName: "3", Time: startTime.Add(12 * time.Minute), Red1: 1, Red2: 2, Red3: 7, Blue1: 4, Blue2: 10,
+			Blue3: 5, Blue3IsSurrogate: true},
+	}
+	quality := EvaluateSchedule(matches)
+	assert.Equal(t, 3, quality.NumMatches)
+	assert.Equal(t, 12, len(quality.Teams))
+	assert.Equal(t, 1, quality.MinTurnaround)
+	assert.Equal(t, 6*time.Minute, quality.MinTurnaroundTime)
+	assert.Equal(t, 2, quality.PartnerDuplicates) // 1-2 and 4-5.
+	assert.Equal(t, 5, quality.OpponentDuplicates)
+	assert.Equal(t, 2, quality.MaxPartnerCount)
+	assert.Equal(t, 2, quality.MaxOpponentCount)
+	assert.Equal(t, 2, quality.MaxColorImbalance)
+	assert.True(t, quality.Score > 0)
+
+	team1 := quality.Teams[0]
+	assert.Equal(t, 1, team1.TeamId)
+	assert.Equal(t, 2, team1.NumMatches)
+	assert.Equal(t, 2, team1.RedCount)
+	assert.Equal(t, 0, team1.BlueCount)
+	assert.Equal(t, 2, team1.StationCounts[0])
+	assert.Equal(t, 1, team1.PartnerDuplicates)
+	assert.Equal(t, 2, team1.OpponentDuplicates)
+
+	if assert.Equal(t, 1, len(quality.Surrogates)) {
+		assert.Equal(t, ScheduleSurrogate{5, "3", "B3", 2, 2}, quality.Surrogates[0])
+	}
+	assert.Equal(t, 0, quality.MisplacedSurrogates)
+
+	// An empty schedule should not cause any trouble.
+	quality = EvaluateSchedule([]Match{})
+	assert.Equal(t, 0, quality.Score)
+	assert.Equal(t, 0, len(quality.Teams))
+}
+
+func TestEvaluateGeneratedSchedule(t *testing.T) {
+	anonSchedule, err := generateAnonSchedule(18, 6, 18, nil, 0)
+	assert.Nil(t, err)
+	matches := make([]Match, len(anonSchedule))
+	for i, anonMatch := range anonSchedule {
+		matches[i] = Match{Red1: anonMatch[0], Red2: anonMatch[2], Red3: anonMatch[4], Blue1: anonMatch[6],
+			Blue2: anonMatch[8], Blue3: anonMatch[10]}
+	}
+	quality := EvaluateSchedule(matches)
+	assert.Equal(t, 18, len(quality.Teams))
+	assert.True(t, quality.MinTurnaround > 1)
+	assert.True(t, quality.MaxColorImbalance <= 2)
+}
+
+func TestScheduleQualityReport(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+
+	db.CreateMatch(&Match{Type: "qualification", DisplayName: "1", Red1: 254, Red2: 1114, Red3: 2056, Blue1: 1678,
+		Blue2: 148, Blue3: 971})
+	db.CreateMatch(&Match{Type: "qualification", DisplayName: "2", Red1: 254, Red2: 1114, Red3: 118, Blue1: 1678,
+		Blue2: 148, Blue3: 971, Red3IsSurrogate: true})
+	recorder := getHttpResponse("/reports/schedule_quality/qualification")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Saved qualification schedule")
+	assert.Contains(t, recorder.Body.String(), "2056")
+	assert.Contains(t, recorder.Body.String(), "R3")
+}
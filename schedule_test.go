@@ -11,13 +11,23 @@ import (
 	"time"
 )
 
-func TestNonExistentSchedule(t *testing.T) {
+func TestScheduleWithoutTemplate(t *testing.T) {
 	teams := make([]Team, 6)
-	scheduleBlocks := []ScheduleBlock{{time.Unix(0, 0).UTC(), 2, 60}}
-	_, err := BuildRandomSchedule(teams, scheduleBlocks, "test")
-	expectedErr := "No schedule template exists for 6 teams and 2 matches"
+	for i := 0; i < 6; i++ {
+		teams[i].Id = i + 101
+	}
+	scheduleBlocks := []ScheduleBlock{{0, "", time.Unix(0, 0).UTC(), 2, 60}}
+	matches, err := BuildRandomSchedule(teams, scheduleBlocks, "test", 0)
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(matches)) {
+		for _, match := range matches {
+			assert.Equal(t, 621, match.Red1+match.Red2+match.Red3+match.Blue1+match.Blue2+match.Blue3)
+		}
+	}
+
+	_, err = BuildRandomSchedule(teams[:5], scheduleBlocks, "test", 0)
 	if assert.NotNil(t, err) {
-		assert.Equal(t, expectedErr, err.Error())
+		assert.Equal(t, "Must have at least 6 teams to generate a schedule", err.Error())
 	}
 }
 
@@ -27,8 +37,8 @@ func TestMalformedSchedule(t *testing.T) {
 	scheduleFile.WriteString("1,0,2,0,3,0,4,0,5,0,6,0\n6,0,5,0,4,0,3,0,2,0,1,0\n")
 	scheduleFile.Close()
 	teams := make([]Team, 6)
-	scheduleBlocks := []ScheduleBlock{{time.Unix(0, 0).UTC(), 1, 60}}
-	_, err := BuildRandomSchedule(teams, scheduleBlocks, "test")
+	scheduleBlocks := []ScheduleBlock{{0, "", time.Unix(0, 0).UTC(), 1, 60}}
+	_, err := BuildRandomSchedule(teams, scheduleBlocks, "test", 0)
 	expectedErr := "Schedule file contains 2 matches, expected 1"
 	if assert.NotNil(t, err) {
 		assert.Equal(t, expectedErr, err.Error())
@@ -38,7 +48,7 @@ func TestMalformedSchedule(t *testing.T) {
 	scheduleFile, _ = os.Create("schedules/6_1.csv")
 	scheduleFile.WriteString("1,0,asdf,0,3,0,4,0,5,0,6,0\n")
 	scheduleFile.Close()
-	_, err = BuildRandomSchedule(teams, scheduleBlocks, "test")
+	_, err = BuildRandomSchedule(teams, scheduleBlocks, "test", 0)
 	if assert.NotNil(t, err) {
 		assert.Contains(t, err.Error(), "strconv.ParseInt")
 	}
@@ -52,23 +62,23 @@ func TestScheduleTeams(t *testing.T) {
 	for i := 0; i < numTeams; i++ {
 		teams[i].Id = i + 101
 	}
-	scheduleBlocks := []ScheduleBlock{{time.Unix(0, 0).UTC(), 6, 60}}
-	matches, err := BuildRandomSchedule(teams, scheduleBlocks, "test")
+	scheduleBlocks := []ScheduleBlock{{0, "", time.Unix(0, 0).UTC(), 6, 60}}
+	matches, err := BuildRandomSchedule(teams, scheduleBlocks, "test", 0)
 	assert.Nil(t, err)
-	assert.Equal(t, Match{Type: "test", DisplayName: "1", Time: time.Unix(0, 0).UTC(), Red1: 115, Red2: 111,
+	assert.Equal(t, Match{Type: "test", DisplayName: "1", Time: time.Unix(0, 0).UTC(), Red1: 104, Red2: 111,
 		Red3: 108, Blue1: 109, Blue2: 116, Blue3: 117, RedDefense1: "LB", RedDefense2: "RW", RedDefense3: "RT",
 		RedDefense4: "R", RedDefense5: "M", BlueDefense1: "LB", BlueDefense2: "RW", BlueDefense3: "RT",
 		BlueDefense4: "R", BlueDefense5: "M"}, matches[0])
 	assert.Equal(t, Match{Type: "test", DisplayName: "2", Time: time.Unix(60, 0).UTC(), Red1: 114, Red2: 112,
-		Red3: 103, Blue1: 101, Blue2: 104, Blue3: 118, RedDefense1: "LB", RedDefense2: "RW", RedDefense3: "RT",
+		Red3: 103, Blue1: 101, Blue2: 115, Blue3: 118, RedDefense1: "LB", RedDefense2: "RW", RedDefense3: "RT",
 		RedDefense4: "R", RedDefense5: "M", BlueDefense1: "LB", BlueDefense2: "RW", BlueDefense3: "RT",
 		BlueDefense4: "R", BlueDefense5: "M"}, matches[1])
-	assert.Equal(t, Match{Type: "test", DisplayName: "3", Time: time.Unix(120, 0).UTC(), Red1: 110, Red2: 107,
+	assert.Equal(t, Match{Type: "test", DisplayName: "3", Time: time.Unix(120, 0).UTC(), Red1: 110, Red2: 109,
 		Red3: 105, Blue1: 106, Blue2: 113, Blue3: 102, RedDefense1: "LB", RedDefense2: "RW", RedDefense3: "RT",
 		RedDefense4: "R", RedDefense5: "M", BlueDefense1: "LB", BlueDefense2: "RW", BlueDefense3: "RT",
 		BlueDefense4: "R", BlueDefense5: "M"}, matches[2])
-	assert.Equal(t, Match{Type: "test", DisplayName: "4", Time: time.Unix(180, 0).UTC(), Red1: 112, Red2: 108,
-		Red3: 109, Blue1: 101, Blue2: 111, Blue3: 103, RedDefense1: "LB", RedDefense2: "RT", RedDefense3: "M",
+	assert.Equal(t, Match{Type: "test", DisplayName: "4", Time: time.Unix(180, 0).UTC(), Red1: 116, Red2: 108,
+		Red3: 107, Blue1: 101, Blue2: 111, Blue3: 103, RedDefense1: "LB", RedDefense2: "RT", RedDefense3: "M",
 		RedDefense4: "CDF", RedDefense5: "R", BlueDefense1: "LB", BlueDefense2: "RT", BlueDefense3: "M",
 		BlueDefense4: "CDF", BlueDefense5: "R"}, matches[3])
 	assert.Equal(t, Match{Type: "test", DisplayName: "5", Time: time.Unix(240, 0).UTC(), Red1: 113, Red2: 117,
@@ -76,22 +86,22 @@ func TestScheduleTeams(t *testing.T) {
 		RedDefense4: "CDF", RedDefense5: "R", BlueDefense1: "LB", BlueDefense2: "RT", BlueDefense3: "M",
 		BlueDefense4: "CDF", BlueDefense5: "R"}, matches[4])
 	assert.Equal(t, Match{Type: "test", DisplayName: "6", Time: time.Unix(300, 0).UTC(), Red1: 118, Red2: 105,
-		Red3: 106, Blue1: 107, Blue2: 104, Blue3: 116, RedDefense1: "LB", RedDefense2: "RT", RedDefense3: "M",
+		Red3: 106, Blue1: 107, Blue2: 104, Blue3: 112, RedDefense1: "LB", RedDefense2: "RT", RedDefense3: "M",
 		RedDefense4: "CDF", RedDefense5: "R", BlueDefense1: "LB", BlueDefense2: "RT", BlueDefense3: "M",
 		BlueDefense4: "CDF", BlueDefense5: "R"}, matches[5])
 
 	// Check with excess room for matches in the schedule.
-	scheduleBlocks = []ScheduleBlock{{time.Unix(0, 0).UTC(), 7, 60}}
-	matches, err = BuildRandomSchedule(teams, scheduleBlocks, "test")
+	scheduleBlocks = []ScheduleBlock{{0, "", time.Unix(0, 0).UTC(), 7, 60}}
+	matches, err = BuildRandomSchedule(teams, scheduleBlocks, "test", 0)
 	assert.Nil(t, err)
 }
 
 func TestScheduleTiming(t *testing.T) {
 	teams := make([]Team, 18)
-	scheduleBlocks := []ScheduleBlock{{time.Unix(100, 0).UTC(), 10, 75},
-		{time.Unix(20000, 0).UTC(), 5, 1000},
-		{time.Unix(100000, 0).UTC(), 15, 29}}
-	matches, err := BuildRandomSchedule(teams, scheduleBlocks, "test")
+	scheduleBlocks := []ScheduleBlock{{0, "", time.Unix(100, 0).UTC(), 10, 75},
+		{0, "", time.Unix(20000, 0).UTC(), 5, 1000},
+		{0, "", time.Unix(100000, 0).UTC(), 15, 29}}
+	matches, err := BuildRandomSchedule(teams, scheduleBlocks, "test", 0)
 	assert.Nil(t, err)
 	assert.Equal(t, time.Unix(100, 0).UTC(), matches[0].Time)
 	assert.Equal(t, time.Unix(775, 0).UTC(), matches[9].Time)
@@ -109,8 +119,8 @@ func TestScheduleSurrogates(t *testing.T) {
 	for i := 0; i < numTeams; i++ {
 		teams[i].Id = i + 101
 	}
-	scheduleBlocks := []ScheduleBlock{{time.Unix(0, 0).UTC(), 64, 60}}
-	matches, _ := BuildRandomSchedule(teams, scheduleBlocks, "test")
+	scheduleBlocks := []ScheduleBlock{{0, "", time.Unix(0, 0).UTC(), 64, 60}}
+	matches, _ := BuildRandomSchedule(teams, scheduleBlocks, "test", 0)
 	for i, match := range matches {
 		if i == 13 || i == 14 {
 			if !match.Red1IsSurrogate || match.Red2IsSurrogate || match.Red3IsSurrogate ||
@@ -125,3 +135,28 @@ func TestScheduleSurrogates(t *testing.T) {
 		}
 	}
 }
+
+func TestScheduleVariantsDifferInQuality(t *testing.T) {
+	teams := make([]Team, 38)
+	for i := 0; i < 38; i++ {
+		teams[i].Id = i + 101
+	}
+	scheduleBlocks := []ScheduleBlock{{0, "", time.Unix(0, 0).UTC(), 64, 60}}
+
+	// Shuffles of the same variant only differ in team assignment, which doesn't affect their quality.
+	matches1, err := BuildRandomSchedule(teams, scheduleBlocks, "test", 0)
+	assert.Nil(t, err)
+	matches2, err := BuildRandomSchedule(teams, scheduleBlocks, "test", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, EvaluateSchedule(matches1).Score, EvaluateSchedule(matches2).Score)
+
+	// Other variants should be distinct schedules, not all of the same quality.
+	scores := make(map[int]bool)
+	for variant := 0; variant < 4; variant++ {
+		matches, err := BuildRandomSchedule(teams, scheduleBlocks, "test", variant)
+		assert.Nil(t, err)
+		assert.Equal(t, 64, len(matches))
+		scores[EvaluateSchedule(matches).Score] = true
+	}
+	assert.True(t, len(scores) > 1)
+}
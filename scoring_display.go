@@ -139,6 +139,8 @@ func ScoringDisplayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		// The scoring interface records the Stronghold-specific scoring data.
+		strongholdScore := getStrongholdScore(&(*score).CurrentScore)
 		switch messageType {
 		case "defenseCrossed":
 			position, ok := data.(string)
@@ -151,12 +153,11 @@ func ScoringDisplayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 				websocket.WriteError(err.Error())
 				continue
 			}
-			if (*score).CurrentScore.AutoDefensesCrossed[intPosition-1]+
-				(*score).CurrentScore.DefensesCrossed[intPosition-1] < 2 {
+			if strongholdScore.AutoDefensesCrossed[intPosition-1]+strongholdScore.DefensesCrossed[intPosition-1] < 2 {
 				if !autoCommitted {
-					(*score).CurrentScore.AutoDefensesCrossed[intPosition-1]++
+					strongholdScore.AutoDefensesCrossed[intPosition-1]++
 				} else {
-					(*score).CurrentScore.DefensesCrossed[intPosition-1]++
+					strongholdScore.DefensesCrossed[intPosition-1]++
 				}
 			}
 		case "undoDefenseCrossed":
@@ -171,80 +172,80 @@ func ScoringDisplayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 				continue
 			}
 			if !autoCommitted {
-				if (*score).CurrentScore.AutoDefensesCrossed[intPosition-1] > 0 {
-					(*score).CurrentScore.AutoDefensesCrossed[intPosition-1]--
+				if strongholdScore.AutoDefensesCrossed[intPosition-1] > 0 {
+					strongholdScore.AutoDefensesCrossed[intPosition-1]--
 				}
 			} else {
-				if (*score).CurrentScore.DefensesCrossed[intPosition-1] > 0 {
-					(*score).CurrentScore.DefensesCrossed[intPosition-1]--
+				if strongholdScore.DefensesCrossed[intPosition-1] > 0 {
+					strongholdScore.DefensesCrossed[intPosition-1]--
 				}
 			}
 		case "autoDefenseReached":
 			if !autoCommitted {
-				if (*score).CurrentScore.AutoDefensesReached < 3 {
-					(*score).CurrentScore.AutoDefensesReached++
+				if strongholdScore.AutoDefensesReached < 3 {
+					strongholdScore.AutoDefensesReached++
 				}
 			}
 		case "undoAutoDefenseReached":
 			if !autoCommitted {
-				if (*score).CurrentScore.AutoDefensesReached > 0 {
-					(*score).CurrentScore.AutoDefensesReached--
+				if strongholdScore.AutoDefensesReached > 0 {
+					strongholdScore.AutoDefensesReached--
 				}
 			}
 		case "highGoal":
 			if !autoCommitted {
-				(*score).CurrentScore.AutoHighGoals++
+				strongholdScore.AutoHighGoals++
 			} else {
-				(*score).CurrentScore.HighGoals++
+				strongholdScore.HighGoals++
 			}
 		case "undoHighGoal":
 			if !autoCommitted {
-				if (*score).CurrentScore.AutoHighGoals > 0 {
-					(*score).CurrentScore.AutoHighGoals--
+				if strongholdScore.AutoHighGoals > 0 {
+					strongholdScore.AutoHighGoals--
 				}
 			} else {
-				if (*score).CurrentScore.HighGoals > 0 {
-					(*score).CurrentScore.HighGoals--
+				if strongholdScore.HighGoals > 0 {
+					strongholdScore.HighGoals--
 				}
 			}
 		case "lowGoal":
 			if !autoCommitted {
-				(*score).CurrentScore.AutoLowGoals++
+				strongholdScore.AutoLowGoals++
 			} else {
-				(*score).CurrentScore.LowGoals++
+				strongholdScore.LowGoals++
 			}
 		case "undoLowGoal":
 			if !autoCommitted {
-				if (*score).CurrentScore.AutoLowGoals > 0 {
-					(*score).CurrentScore.AutoLowGoals--
+				if strongholdScore.AutoLowGoals > 0 {
+					strongholdScore.AutoLowGoals--
 				}
 			} else {
-				if (*score).CurrentScore.LowGoals > 0 {
-					(*score).CurrentScore.LowGoals--
+				if strongholdScore.LowGoals > 0 {
+					strongholdScore.LowGoals--
 				}
 			}
 		case "challenge":
 			if autoCommitted {
-				if (*score).CurrentScore.Challenges < 3 {
-					(*score).CurrentScore.Challenges++
+				if strongholdScore.Challenges < 3 {
+					strongholdScore.Challenges++
 				}
 			}
 		case "undoChallenge":
 			if autoCommitted {
-				if (*score).CurrentScore.Challenges > 0 {
-					(*score).CurrentScore.Challenges--
+				if strongholdScore.Challenges > 0 {
+					strongholdScore.Challenges--
 				}
 			}
 		case "scale":
 			if autoCommitted {
-				if (*score).CurrentScore.Scales < 3 {
-					(*score).CurrentScore.Scales++
+				if strongholdScore.Scales < 3 {
+					strongholdScore.Scales++
 				}
 			}
 		case "undoScale":
 			if autoCommitted {
-				if (*score).CurrentScore.Scales > 0 {
-					(*score).CurrentScore.Scales--
+				if strongholdScore.Scales > 0 {
+					strongholdScore.Scales--
 				}
 			}
 		case "commit":
@@ -268,6 +269,7 @@ func ScoringDisplayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 			continue
 		}
 
+		(*score).CurrentScore.GameScore = strongholdScore
 		mainArena.realtimeScoreNotifier.Notify(nil)
 
 		// Send out the score again after handling the command, as it most likely changed as a result.
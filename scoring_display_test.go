@@ -82,12 +82,14 @@ func TestScoringDisplayWebsocket(t *testing.T) {
 		readWebsocketType(t, blueWs, "score")
 	}
 
-	assert.Equal(t, [5]int{0, 2, 0, 0, 1}, mainArena.redRealtimeScore.CurrentScore.AutoDefensesCrossed)
-	assert.Equal(t, 1, mainArena.redRealtimeScore.CurrentScore.AutoDefensesReached)
-	assert.Equal(t, 1, mainArena.redRealtimeScore.CurrentScore.AutoHighGoals)
-	assert.Equal(t, 1, mainArena.redRealtimeScore.CurrentScore.AutoLowGoals)
-	assert.Equal(t, [5]int{1, 0, 0, 0, 0}, mainArena.blueRealtimeScore.CurrentScore.AutoDefensesCrossed)
-	assert.Equal(t, 2, mainArena.blueRealtimeScore.CurrentScore.AutoDefensesReached)
+	redScore := getStrongholdScore(&mainArena.redRealtimeScore.CurrentScore)
+	blueScore := getStrongholdScore(&mainArena.blueRealtimeScore.CurrentScore)
+	assert.Equal(t, [5]int{0, 2, 0, 0, 1}, redScore.AutoDefensesCrossed)
+	assert.Equal(t, 1, redScore.AutoDefensesReached)
+	assert.Equal(t, 1, redScore.AutoHighGoals)
+	assert.Equal(t, 1, redScore.AutoLowGoals)
+	assert.Equal(t, [5]int{1, 0, 0, 0, 0}, blueScore.AutoDefensesCrossed)
+	assert.Equal(t, 2, blueScore.AutoDefensesReached)
 
 	redWs.Write("defenseCrossed", "2")
 	blueWs.Write("autoDefenseReached", nil)
@@ -110,20 +112,22 @@ func TestScoringDisplayWebsocket(t *testing.T) {
 	}
 
 	// Make sure auto scores haven't changed in teleop.
-	assert.Equal(t, [5]int{0, 2, 0, 0, 1}, mainArena.redRealtimeScore.CurrentScore.AutoDefensesCrossed)
-	assert.Equal(t, 1, mainArena.redRealtimeScore.CurrentScore.AutoDefensesReached)
-	assert.Equal(t, 1, mainArena.redRealtimeScore.CurrentScore.AutoHighGoals)
-	assert.Equal(t, 1, mainArena.redRealtimeScore.CurrentScore.AutoLowGoals)
-	assert.Equal(t, [5]int{1, 0, 0, 0, 0}, mainArena.blueRealtimeScore.CurrentScore.AutoDefensesCrossed)
-	assert.Equal(t, 2, mainArena.blueRealtimeScore.CurrentScore.AutoDefensesReached)
+	redScore = getStrongholdScore(&mainArena.redRealtimeScore.CurrentScore)
+	blueScore = getStrongholdScore(&mainArena.blueRealtimeScore.CurrentScore)
+	assert.Equal(t, [5]int{0, 2, 0, 0, 1}, redScore.AutoDefensesCrossed)
+	assert.Equal(t, 1, redScore.AutoDefensesReached)
+	assert.Equal(t, 1, redScore.AutoHighGoals)
+	assert.Equal(t, 1, redScore.AutoLowGoals)
+	assert.Equal(t, [5]int{1, 0, 0, 0, 0}, blueScore.AutoDefensesCrossed)
+	assert.Equal(t, 2, blueScore.AutoDefensesReached)
 
-	assert.Equal(t, [5]int{0, 0, 1, 0, 1}, mainArena.redRealtimeScore.CurrentScore.DefensesCrossed)
-	assert.Equal(t, 1, mainArena.redRealtimeScore.CurrentScore.HighGoals)
-	assert.Equal(t, 1, mainArena.redRealtimeScore.CurrentScore.LowGoals)
-	assert.Equal(t, 1, mainArena.redRealtimeScore.CurrentScore.Challenges)
-	assert.Equal(t, [5]int{0, 0, 1, 0, 0}, mainArena.blueRealtimeScore.CurrentScore.DefensesCrossed)
-	assert.Equal(t, 0, mainArena.blueRealtimeScore.CurrentScore.Challenges)
-	assert.Equal(t, 1, mainArena.blueRealtimeScore.CurrentScore.Scales)
+	assert.Equal(t, [5]int{0, 0, 1, 0, 1}, redScore.DefensesCrossed)
+	assert.Equal(t, 1, redScore.HighGoals)
+	assert.Equal(t, 1, redScore.LowGoals)
+	assert.Equal(t, 1, redScore.Challenges)
+	assert.Equal(t, [5]int{0, 0, 1, 0, 0}, blueScore.DefensesCrossed)
+	assert.Equal(t, 0, blueScore.Challenges)
+	assert.Equal(t, 1, blueScore.Scales)
 
 	// Test committing logic.
 	redWs.Write("commitMatch", nil)
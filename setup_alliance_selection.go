@@ -192,6 +192,7 @@ func AllianceSelectionFinalizeHandler(w http.ResponseWriter, r *http.Request) {
 		handleWebErr(w, err)
 		return
 	}
+	mainArena.bracketNotifier.Notify(nil)
 
 	// Reset yellow cards.
 	err = db.CalculateTeamCards("elimination")
@@ -0,0 +1,105 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for managing awards.
+
+package main
+
+import (
+	"html/template"
+	"net/http"
+	"strconv"
+)
+
+// Shows the awards configuration page.
+func AwardsGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !UserIsAdmin(w, r) {
+		return
+	}
+
+	template, err := template.ParseFiles("templates/setup_awards.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	awards, err := db.GetAllAwards()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	teams, err := db.GetAllTeams()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*EventSettings
+		Awards []Award
+		Teams  []Team
+	}{eventSettings, awards, teams}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Saves the new or modified award to the database and updates its lower thirds to match.
+func AwardsPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !UserIsAdmin(w, r) {
+		return
+	}
+
+	awardId, _ := strconv.Atoi(r.PostFormValue("id"))
+	award, err := db.GetAwardById(awardId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if r.PostFormValue("action") == "delete" {
+		if award != nil {
+			err = db.DeleteAwardAndLowerThirds(award)
+			if err != nil {
+				handleWebErr(w, err)
+				return
+			}
+		}
+	} else {
+		teamId, _ := strconv.Atoi(r.PostFormValue("teamId"))
+		if award == nil {
+			award = &Award{AwardName: r.PostFormValue("awardName"), TeamId: teamId,
+				PersonName: r.PostFormValue("personName")}
+			err = db.CreateAward(award)
+		} else {
+			award.AwardName = r.PostFormValue("awardName")
+			award.TeamId = teamId
+			award.PersonName = r.PostFormValue("personName")
+			err = db.SaveAward(award)
+		}
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		err = db.UpdateAwardLowerThirds(award)
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	}
+
+	http.Redirect(w, r, "/setup/awards", 302)
+}
+
+// Publishes the awards to The Blue Alliance.
+func AwardsPublishHandler(w http.ResponseWriter, r *http.Request) {
+	if !UserIsAdmin(w, r) {
+		return
+	}
+
+	err := PublishAwards()
+	if err != nil {
+		http.Error(w, "Failed to publish awards: "+err.Error(), 500)
+		return
+	}
+	http.Redirect(w, r, "/setup/awards", 302)
+}
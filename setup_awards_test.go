@@ -0,0 +1,83 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package main
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetupAwards(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+	mainArena.Setup()
+	db.CreateTeam(&Team{Id: 254, Nickname: "The Cheesy Poofs"})
+
+	recorder := postHttpResponse("/setup/awards", "action=save&awardName=Winner&teamId=254")
+	assert.Equal(t, 302, recorder.Code)
+	recorder = postHttpResponse("/setup/awards", "action=save&awardName=Volunteer of the Year&personName=Bob")
+	assert.Equal(t, 302, recorder.Code)
+	recorder = getHttpResponse("/setup/awards")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Winner")
+	assert.Contains(t, recorder.Body.String(), "Volunteer of the Year")
+	lowerThirds, _ := db.GetAllLowerThirds()
+	if assert.Equal(t, 4, len(lowerThirds)) {
+		assert.Equal(t, "Team 254 The Cheesy Poofs", lowerThirds[1].BottomText)
+		assert.Equal(t, "Bob", lowerThirds[3].BottomText)
+	}
+
+	recorder = postHttpResponse("/setup/awards", "action=save&id=2&awardName=Volunteer of the Year&personName=Alice")
+	assert.Equal(t, 302, recorder.Code)
+	lowerThirds, _ = db.GetAllLowerThirds()
+	if assert.Equal(t, 4, len(lowerThirds)) {
+		assert.Equal(t, "Alice", lowerThirds[3].BottomText)
+	}
+
+	recorder = postHttpResponse("/setup/awards", "action=delete&id=1")
+	assert.Equal(t, 302, recorder.Code)
+	recorder = getHttpResponse("/setup/awards")
+	assert.Equal(t, 200, recorder.Code)
+	assert.NotContains(t, recorder.Body.String(), "value=\"Winner\"")
+	lowerThirds, _ = db.GetAllLowerThirds()
+	assert.Equal(t, 2, len(lowerThirds))
+}
+
+func TestSetupAwardsPublish(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+	eventSettings.TbaPublishingEnabled = true
+	defer func() { eventSettings.TbaPublishingEnabled = false }()
+	db.CreateAward(&Award{0, "Winner", 254, ""})
+	db.CreateAward(&Award{0, "Volunteer of the Year", 0, "Bob"})
+
+	// Mock the TBA server.
+	tbaServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.String(), "awards/update")
+		var reader bytes.Buffer
+		reader.ReadFrom(r.Body)
+		assert.Equal(t, "[{\"name_str\":\"Winner\",\"team_key\":\"frc254\"},{\"name_str\":\"Volunteer of the Year\","+
+			"\"awardee\":\"Bob\"}]", reader.String())
+	}))
+	defer tbaServer.Close()
+	tbaBaseUrl = tbaServer.URL
+
+	recorder := getHttpResponse("/setup/awards")
+	assert.Contains(t, recorder.Body.String(), "Publish Awards to TBA")
+	recorder = postHttpResponse("/setup/awards/publish", "")
+	assert.Equal(t, 302, recorder.Code)
+}
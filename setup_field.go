@@ -8,6 +8,7 @@ package main
 import (
 	"html/template"
 	"net/http"
+	"strconv"
 )
 
 // Shows the field configuration page.
@@ -21,11 +22,17 @@ func FieldGetHandler(w http.ResponseWriter, r *http.Request) {
 		handleWebErr(w, err)
 		return
 	}
+	dsSimulatorStations, dsSimulatorEvents := dsSimulator.Status()
 	data := struct {
 		*EventSettings
 		AllianceStationDisplays map[string]string
 		LightsMode              string
-	}{eventSettings, mainArena.allianceStationDisplays, mainArena.lights.currentMode}
+		DsSimulatorRunning      bool
+		DsSimulatorStations     []SimulatedDriverStation
+		DsSimulatorEvents       []DsSimulatorEvent
+		DsSimulatorEventTypes   map[string]string
+	}{eventSettings, mainArena.allianceStationDisplays, mainArena.lights.currentMode, dsSimulator.Running,
+		dsSimulatorStations, dsSimulatorEvents, dsSimulatorEventTypes}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
 		handleWebErr(w, err)
@@ -65,3 +72,32 @@ func FieldLightsPostHandler(w http.ResponseWriter, r *http.Request) {
 	mainArena.lights.SetMode(r.PostFormValue("mode"))
 	http.Redirect(w, r, "/setup/field", 302)
 }
+
+// Starts or stops the driver station simulator, or edits its script of simulated faults.
+func FieldDsSimulatorPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !UserIsAdmin(w, r) {
+		return
+	}
+
+	switch r.PostFormValue("action") {
+	case "start":
+		if err := dsSimulator.Start(dsListeners.Config()); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	case "stop":
+		dsSimulator.Stop()
+	case "addEvent":
+		startSec, _ := strconv.ParseFloat(r.PostFormValue("startSec"), 64)
+		durationSec, _ := strconv.ParseFloat(r.PostFormValue("durationSec"), 64)
+		event := DsSimulatorEvent{AllianceStation: r.PostFormValue("allianceStation"), Type: r.PostFormValue("type"),
+			StartSec: startSec, DurationSec: durationSec}
+		if err := dsSimulator.AddEvent(event); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	case "clearEvents":
+		dsSimulator.ClearEvents()
+	}
+	http.Redirect(w, r, "/setup/field", 302)
+}
@@ -34,3 +34,31 @@ func TestSetupField(t *testing.T) {
 	assert.Equal(t, 302, recorder.Code)
 	assert.Equal(t, "strobe", mainArena.lights.currentMode)
 }
+
+func TestSetupFieldDsSimulator(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+	mainArena.Setup()
+	defer dsSimulator.ClearEvents()
+
+	recorder := postHttpResponse("/setup/field/ds_simulator", "action=addEvent&allianceStation=B2&type=low_battery&"+
+		"startSec=20&durationSec=10")
+	assert.Equal(t, 302, recorder.Code)
+	recorder = postHttpResponse("/setup/field/ds_simulator", "action=addEvent&allianceStation=B2&type=blorpy&"+
+		"startSec=20&durationSec=10")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid simulated event type")
+	recorder = getHttpResponse("/setup/field")
+	assert.Contains(t, recorder.Body.String(), "Start Simulator")
+	assert.Contains(t, recorder.Body.String(), "<td>Low battery</td>")
+
+	recorder = postHttpResponse("/setup/field/ds_simulator", "action=clearEvents")
+	assert.Equal(t, 302, recorder.Code)
+	recorder = getHttpResponse("/setup/field")
+	assert.NotContains(t, recorder.Body.String(), "<td>Low battery</td>")
+}
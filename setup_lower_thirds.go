@@ -149,6 +149,8 @@ func saveLowerThird(lowerThird *LowerThird) error {
 	if oldLowerThird == nil {
 		err = db.CreateLowerThird(lowerThird)
 	} else {
+		// The page doesn't know about award links, so keep the existing one.
+		lowerThird.AwardId = oldLowerThird.AwardId
 		err = db.SaveLowerThird(lowerThird)
 	}
 	if err != nil {
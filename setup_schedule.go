@@ -7,17 +7,31 @@ package main
 
 import (
 	"fmt"
+	"github.com/gorilla/mux"
 	"html/template"
 	"net/http"
+	"reflect"
 	"strconv"
 	"time"
 )
 
+const maxScheduleCandidates = 10
+
+// A generated schedule that is held for comparison against other random shuffles before one is saved.
+type ScheduleCandidate struct {
+	Matches          []Match
+	TeamFirstMatches map[int]string
+	Quality          *ScheduleQuality
+}
+
 // Global vars to hold schedules that are in the process of being generated.
 var cachedMatchType string
 var cachedScheduleBlocks []ScheduleBlock
 var cachedMatches []Match
 var cachedTeamFirstMatches map[int]string
+var cachedScheduleCandidates []ScheduleCandidate
+var cachedCandidateIndex int
+var cachedScheduleVariant int
 
 // Shows the schedule editing page.
 func ScheduleGetHandler(w http.ResponseWriter, r *http.Request) {
@@ -25,11 +39,31 @@ func ScheduleGetHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Reload the blocks that the saved schedule of the requested type was generated from, if there are any.
+	if matchType := r.URL.Query().Get("matchType"); matchType != "" {
+		scheduleBlocks, err := db.GetScheduleBlocksByMatchType(matchType)
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if matchType != cachedMatchType {
+			cachedMatches = nil
+			cachedTeamFirstMatches = nil
+			cachedScheduleCandidates = nil
+			cachedScheduleVariant = 0
+		}
+		cachedMatchType = matchType
+		if len(scheduleBlocks) > 0 {
+			cachedScheduleBlocks = scheduleBlocks
+		}
+	}
+
 	if len(cachedScheduleBlocks) == 0 {
 		tomorrow := time.Now().AddDate(0, 0, 1)
 		location, _ := time.LoadLocation("Local")
 		startTime := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 9, 0, 0, 0, location)
-		cachedScheduleBlocks = append(cachedScheduleBlocks, ScheduleBlock{startTime, 10, 360})
+		cachedScheduleBlocks = append(cachedScheduleBlocks, ScheduleBlock{StartTime: startTime, NumMatches: 10,
+			MatchSpacingSec: 360})
 		cachedMatchType = "practice"
 	}
 	renderSchedule(w, r, "")
@@ -42,8 +76,14 @@ func ScheduleGeneratePostHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	r.ParseForm()
-	cachedMatchType = r.PostFormValue("matchType")
 	scheduleBlocks, err := getScheduleBlocks(r)
+
+	// Only keep previous shuffles around for comparison if they were generated from the same parameters.
+	if r.PostFormValue("matchType") != cachedMatchType || !reflect.DeepEqual(scheduleBlocks, cachedScheduleBlocks) {
+		cachedScheduleCandidates = nil
+		cachedScheduleVariant = 0
+	}
+	cachedMatchType = r.PostFormValue("matchType")
 	cachedScheduleBlocks = scheduleBlocks // Show the same blocks even if there is an error.
 	if err != nil {
 		renderSchedule(w, r, "Incomplete or invalid schedule block parameters specified.")
@@ -61,17 +101,19 @@ func ScheduleGeneratePostHandler(w http.ResponseWriter, r *http.Request) {
 			"generating the schedule.")
 		return
 	}
-	if len(teams) < 18 {
-		renderSchedule(w, r, fmt.Sprintf("There are only %d teams. There must be at least 18 teams to generate "+
-			"a schedule.", len(teams)))
+	if len(teams) < teamsPerMatch {
+		renderSchedule(w, r, fmt.Sprintf("There are only %d teams. There must be at least %d teams to generate "+
+			"a schedule.", len(teams), teamsPerMatch))
 		return
 	}
-	matches, err := BuildRandomSchedule(teams, scheduleBlocks, r.PostFormValue("matchType"))
+	// Build a different anonymized schedule for each shuffle so that they can differ in quality, not just in the
+	// assignment of teams.
+	matches, err := BuildRandomSchedule(teams, scheduleBlocks, r.PostFormValue("matchType"), cachedScheduleVariant)
 	if err != nil {
 		renderSchedule(w, r, fmt.Sprintf("Error generating schedule: %s.", err.Error()))
 		return
 	}
-	cachedMatches = matches
+	cachedScheduleVariant++
 
 	// Determine each team's first match.
 	teamFirstMatches := make(map[int]string)
@@ -89,7 +131,30 @@ func ScheduleGeneratePostHandler(w http.ResponseWriter, r *http.Request) {
 		checkTeam(match.Blue2)
 		checkTeam(match.Blue3)
 	}
-	cachedTeamFirstMatches = teamFirstMatches
+
+	cachedScheduleCandidates = append(cachedScheduleCandidates,
+		ScheduleCandidate{matches, teamFirstMatches, EvaluateSchedule(matches)})
+	if len(cachedScheduleCandidates) > maxScheduleCandidates {
+		cachedScheduleCandidates = cachedScheduleCandidates[1:]
+	}
+	selectScheduleCandidate(len(cachedScheduleCandidates) - 1)
+
+	http.Redirect(w, r, "/setup/schedule", 302)
+}
+
+// Makes a previously generated shuffle the one that will be saved.
+func ScheduleCandidateSelectPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !UserIsAdmin(w, r) {
+		return
+	}
+
+	vars := mux.Vars(r)
+	index, _ := strconv.Atoi(vars["index"])
+	if index < 0 || index >= len(cachedScheduleCandidates) {
+		handleWebErr(w, fmt.Errorf("Error: No such generated schedule: %d", index))
+		return
+	}
+	selectScheduleCandidate(index)
 
 	http.Redirect(w, r, "/setup/schedule", 302)
 }
@@ -141,6 +206,13 @@ func ScheduleSavePostHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Store the blocks the schedule was generated from so that it can be re-timed or regenerated later.
+	err = db.SaveScheduleBlocks(cachedMatchType, cachedScheduleBlocks)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
 	// Back up the database.
 	err = db.Backup("post_scheduling")
 	if err != nil {
@@ -165,12 +237,56 @@ func ScheduleSavePostHandler(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/setup/schedule", 302)
 }
 
+func selectScheduleCandidate(index int) {
+	cachedCandidateIndex = index
+	cachedMatches = cachedScheduleCandidates[index].Matches
+	cachedTeamFirstMatches = cachedScheduleCandidates[index].TeamFirstMatches
+}
+
+// Moves a saved schedule block to a new start time, re-timing its unplayed matches without changing the teams.
+func ScheduleBlockShiftPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !UserIsAdmin(w, r) {
+		return
+	}
+
+	vars := mux.Vars(r)
+	blockId, _ := strconv.Atoi(vars["blockId"])
+	block, err := db.GetScheduleBlockById(blockId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if block == nil {
+		handleWebErr(w, fmt.Errorf("Error: No such schedule block: %d", blockId))
+		return
+	}
+	location, _ := time.LoadLocation("Local")
+	startTime, err := time.ParseInLocation("2006-01-02 03:04:05 PM", r.PostFormValue("startTime"), location)
+	if err != nil {
+		renderSchedule(w, r, "Invalid start time specified for the schedule block.")
+		return
+	}
+
+	err = db.ShiftScheduleBlock(block.Id, startTime)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/setup/schedule?matchType=%s", block.MatchType), 302)
+}
+
 func renderSchedule(w http.ResponseWriter, r *http.Request, errorMessage string) {
 	teams, err := db.GetAllTeams()
 	if err != nil {
 		handleWebErr(w, err)
 		return
 	}
+	savedScheduleBlocks, err := db.GetScheduleBlocksByMatchType(cachedMatchType)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
 	template, err := template.ParseFiles("templates/setup_schedule.html", "templates/base.html")
 	if err != nil {
 		handleWebErr(w, err)
@@ -178,14 +294,17 @@ func renderSchedule(w http.ResponseWriter, r *http.Request, errorMessage string)
 	}
 	data := struct {
 		*EventSettings
-		MatchType        string
-		ScheduleBlocks   []ScheduleBlock
-		NumTeams         int
-		Matches          []Match
-		TeamFirstMatches map[int]string
-		ErrorMessage     string
-	}{eventSettings, cachedMatchType, cachedScheduleBlocks, len(teams), cachedMatches, cachedTeamFirstMatches,
-		errorMessage}
+		MatchType           string
+		ScheduleBlocks      []ScheduleBlock
+		SavedScheduleBlocks []ScheduleBlock
+		NumTeams            int
+		Matches             []Match
+		TeamFirstMatches    map[int]string
+		Candidates          []ScheduleCandidate
+		CandidateIndex      int
+		ErrorMessage        string
+	}{eventSettings, cachedMatchType, cachedScheduleBlocks, savedScheduleBlocks, len(teams), cachedMatches,
+		cachedTeamFirstMatches, cachedScheduleCandidates, cachedCandidateIndex, errorMessage}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
 		handleWebErr(w, err)
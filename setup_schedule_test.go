@@ -4,6 +4,7 @@
 package main
 
 import (
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -38,6 +39,25 @@ func TestSetupSchedule(t *testing.T) {
 	assert.Contains(t, recorder.Body.String(), "2014-01-02 11:48:00") // Last match of second block.
 	assert.Contains(t, recorder.Body.String(), "2014-01-03 16:54:00") // Last match of third block.
 
+	// Generate a second shuffle and switch back to the first one.
+	recorder = postHttpResponse("/setup/schedule/generate", postData)
+	assert.Equal(t, 302, recorder.Code)
+	assert.Equal(t, 2, len(cachedScheduleCandidates))
+	assert.Equal(t, 1, cachedCandidateIndex)
+	assert.NotEqual(t, cachedScheduleCandidates[0].Quality.Score, cachedScheduleCandidates[1].Quality.Score)
+	recorder = getHttpResponse("/setup/schedule")
+	assert.Contains(t, recorder.Body.String(), "Generated Shuffles")
+	assert.Contains(t, recorder.Body.String(), "/setup/schedule/candidates/0/select")
+	recorder = postHttpResponse("/setup/schedule/candidates/0/select", "")
+	assert.Equal(t, 302, recorder.Code)
+	assert.Equal(t, 0, cachedCandidateIndex)
+	assert.Equal(t, cachedScheduleCandidates[0].Matches, cachedMatches)
+	recorder = postHttpResponse("/setup/schedule/candidates/5/select", "")
+	assert.Contains(t, recorder.Body.String(), "No such generated schedule")
+	recorder = getHttpResponse("/setup/schedule/quality")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Generated qualification schedule")
+
 	// Save schedule and check that it is published to TBA.
 	tbaBaseUrl = "fakeurl"
 	eventSettings.TbaPublishingEnabled = true
@@ -50,6 +70,22 @@ func TestSetupSchedule(t *testing.T) {
 	assert.Equal(t, int64(1388595600), matches[0].Time.Unix())
 	assert.Equal(t, int64(1388685360), matches[7].Time.Unix())
 	assert.Equal(t, int64(1388782800), matches[24].Time.Unix())
+
+	// Check that the schedule blocks were saved and can be used to re-time the schedule.
+	scheduleBlocks, err := db.GetScheduleBlocksByMatchType("qualification")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(scheduleBlocks))
+	recorder = postHttpResponse(fmt.Sprintf("/setup/schedule/blocks/%d/shift", scheduleBlocks[1].Id),
+		"startTime=2014-01-02 10:56:00 AM")
+	assert.Equal(t, 302, recorder.Code)
+	matches, _ = db.GetMatchesByType("qualification")
+	assert.Equal(t, int64(1388595600), matches[0].Time.Unix())
+	assert.Equal(t, int64(1388685360+3600), matches[7].Time.Unix())
+	assert.Equal(t, int64(1388782800), matches[24].Time.Unix())
+	recorder = getHttpResponse("/setup/schedule?matchType=qualification")
+	assert.Contains(t, recorder.Body.String(), "2014-01-02 10:56:00 AM")
+	recorder = postHttpResponse("/setup/schedule/blocks/1254/shift", "startTime=2014-01-02 10:56:00 AM")
+	assert.Contains(t, recorder.Body.String(), "No such schedule block")
 }
 
 func TestSetupScheduleErrors(t *testing.T) {
@@ -69,22 +105,24 @@ func TestSetupScheduleErrors(t *testing.T) {
 	assert.Contains(t, recorder.Body.String(), "No team list is configured.")
 
 	// Insufficient number of teams.
-	for i := 0; i < 17; i++ {
+	for i := 0; i < 5; i++ {
 		db.CreateTeam(&Team{Id: i + 101})
 	}
 	postData = "numScheduleBlocks=1&startTime0=2014-01-01 09:00:00 AM&numMatches0=7&matchSpacingSec0=480&" +
 		"matchType=practice"
 	recorder = postHttpResponse("/setup/schedule/generate", postData)
 	assert.Equal(t, 200, recorder.Code)
-	assert.Contains(t, recorder.Body.String(), "There must be at least 18 teams to generate a schedule.")
+	assert.Contains(t, recorder.Body.String(), "There must be at least 6 teams to generate a schedule.")
 
-	// More matches per team than schedules exist for.
+	// More matches per team than schedule templates exist for should still be generated.
+	for i := 5; i < 17; i++ {
+		db.CreateTeam(&Team{Id: i + 101})
+	}
 	db.CreateTeam(&Team{Id: 118})
-	postData = "numScheduleBlocks=1&startTime0=2014-01-01 09:00:00 AM&numMatches0=700&matchSpacingSec0=480&" +
+	postData = "numScheduleBlocks=1&startTime0=2014-01-01 09:00:00 AM&numMatches0=120&matchSpacingSec0=480&" +
 		"matchType=practice"
 	recorder = postHttpResponse("/setup/schedule/generate", postData)
-	assert.Equal(t, 200, recorder.Code)
-	assert.Contains(t, recorder.Body.String(), "No schedule template exists for 18 teams and 233 matches")
+	assert.Equal(t, 302, recorder.Code)
 
 	// Incomplete scheduling data received.
 	postData = "numScheduleBlocks=1&startTime0=2014-01-01 09:00:00 AM&numMatches0=&matchSpacingSec0=480&" +
@@ -33,6 +33,7 @@ func SettingsPostHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	previousDsNetworkConfig := getDsNetworkConfig()
 	eventSettings.Name = r.PostFormValue("name")
 	eventSettings.Code = r.PostFormValue("code")
 	match, _ := regexp.MatchString("^#([0-9A-Fa-f]{3}){1,2}$", r.PostFormValue("displayBackgroundColor"))
@@ -41,13 +42,62 @@ func SettingsPostHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	eventSettings.DisplayBackgroundColor = r.PostFormValue("displayBackgroundColor")
+	bracket, ok := eliminationBrackets[r.PostFormValue("elimType")]
+	if !ok {
+		renderSettings(w, r, "Invalid playoff format selected.")
+		return
+	}
 	numAlliances, _ := strconv.Atoi(r.PostFormValue("numElimAlliances"))
-	if numAlliances < 2 || numAlliances > 16 {
-		renderSettings(w, r, "Number of alliances must be between 2 and 16.")
+	if err := bracket.ValidateNumAlliances(numAlliances); err != nil {
+		renderSettings(w, r, err.Error())
+		return
+	}
+	if _, ok := roundRobinPointsRules[r.PostFormValue("roundRobinPointsRule")]; ok {
+		eventSettings.RoundRobinPointsRule = r.PostFormValue("roundRobinPointsRule")
+	} else if r.PostFormValue("elimType") == "roundrobin" {
+		renderSettings(w, r, "Invalid round-robin points rule selected.")
 		return
 	}
 
+	autoDurationSec, err := strconv.Atoi(r.PostFormValue("autoDurationSec"))
+	if err != nil || autoDurationSec < 0 {
+		renderSettings(w, r, "Autonomous period duration must be a non-negative number of seconds.")
+		return
+	}
+	pauseDurationSec, err := strconv.Atoi(r.PostFormValue("pauseDurationSec"))
+	if err != nil || pauseDurationSec < 0 {
+		renderSettings(w, r, "Pause duration must be a non-negative number of seconds.")
+		return
+	}
+	teleopDurationSec, err := strconv.Atoi(r.PostFormValue("teleopDurationSec"))
+	if err != nil || teleopDurationSec < 1 {
+		renderSettings(w, r, "Teleoperated period duration must be at least 1 second.")
+		return
+	}
+	endgameTimeLeftSec, err := strconv.Atoi(r.PostFormValue("endgameTimeLeftSec"))
+	if err != nil || endgameTimeLeftSec < 0 || endgameTimeLeftSec > teleopDurationSec {
+		renderSettings(w, r, "Endgame warning must be between 0 seconds and the teleoperated period duration.")
+		return
+	}
+	fieldResetWarningSec, err := strconv.Atoi(r.PostFormValue("fieldResetWarningSec"))
+	if err != nil || fieldResetWarningSec < 1 {
+		renderSettings(w, r, "Field reset warning threshold must be at least 1 second.")
+		return
+	}
+	fieldResetLimitSec, err := strconv.Atoi(r.PostFormValue("fieldResetLimitSec"))
+	if err != nil || fieldResetLimitSec < fieldResetWarningSec {
+		renderSettings(w, r, "Field reset limit must be no less than the field reset warning threshold.")
+		return
+	}
+
+	eventSettings.ElimType = r.PostFormValue("elimType")
 	eventSettings.NumElimAlliances = numAlliances
+	eventSettings.AutoDurationSec = autoDurationSec
+	eventSettings.PauseDurationSec = pauseDurationSec
+	eventSettings.TeleopDurationSec = teleopDurationSec
+	eventSettings.EndgameTimeLeftSec = endgameTimeLeftSec
+	eventSettings.FieldResetWarningSec = fieldResetWarningSec
+	eventSettings.FieldResetLimitSec = fieldResetLimitSec
 	eventSettings.SelectionRound2Order = r.PostFormValue("selectionRound2Order")
 	eventSettings.SelectionRound3Order = r.PostFormValue("selectionRound3Order")
 	eventSettings.TBADownloadEnabled = r.PostFormValue("TBADownloadEnabled") == "on"
@@ -64,11 +114,56 @@ func SettingsPostHandler(w http.ResponseWriter, r *http.Request) {
 	eventSettings.SwitchAddress = r.PostFormValue("switchAddress")
 	eventSettings.SwitchPassword = r.PostFormValue("switchPassword")
 	eventSettings.BandwidthMonitoringEnabled = r.PostFormValue("bandwidthMonitoringEnabled") == "on"
+	if _, ok := dsProtocols[r.PostFormValue("dsProtocol")]; !ok {
+		renderSettings(w, r, "Invalid driver station protocol selected.")
+		return
+	}
+	eventSettings.DsProtocol = r.PostFormValue("dsProtocol")
+	dsTcpListenPort, ok := parsePortSetting(r.PostFormValue("dsTcpListenPort"), driverStationTcpListenPort)
+	if !ok {
+		renderSettings(w, r, "Driver station TCP port must be between 1 and 65535.")
+		return
+	}
+	dsUdpReceivePort, ok := parsePortSetting(r.PostFormValue("dsUdpReceivePort"), driverStationUdpReceivePort)
+	if !ok {
+		renderSettings(w, r, "Driver station UDP receive port must be between 1 and 65535.")
+		return
+	}
+	dsUdpSendPort, ok := parsePortSetting(r.PostFormValue("dsUdpSendPort"), driverStationUdpSendPort)
+	if !ok {
+		renderSettings(w, r, "Driver station UDP send port must be between 1 and 65535.")
+		return
+	}
+	eventSettings.DsListenAddress = r.PostFormValue("dsListenAddress")
+	if eventSettings.DsListenAddress == "" {
+		eventSettings.DsListenAddress = defaultDsListenAddress
+	}
+	eventSettings.DsListenInterface = r.PostFormValue("dsListenInterface")
+	eventSettings.DsTcpListenPort = dsTcpListenPort
+	eventSettings.DsUdpReceivePort = dsUdpReceivePort
+	eventSettings.DsUdpSendPort = dsUdpSendPort
 	eventSettings.AdminPassword = r.PostFormValue("adminPassword")
 	eventSettings.ReaderPassword = r.PostFormValue("readerPassword")
 	eventSettings.RedDefenseLightsAddress = r.PostFormValue("redDefenseLightsAddress")
 	eventSettings.BlueDefenseLightsAddress = r.PostFormValue("blueDefenseLightsAddress")
 
+	if _, ok := games[r.PostFormValue("game")]; !ok {
+		renderSettings(w, r, "Invalid game selected.")
+		return
+	}
+	if games[r.PostFormValue("game")] != currentGame() {
+		// Stored scores are decoded using the current game, so it can't change underneath them.
+		hasMatchResults, err := db.HasMatchResults()
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if hasMatchResults {
+			renderSettings(w, r, "The game cannot be changed once match results exist; clear all match data first.")
+			return
+		}
+	}
+	eventSettings.Game = r.PostFormValue("game")
 	initialTowerStrength, _ := strconv.Atoi(r.PostFormValue("initialTowerStrength"))
 	if initialTowerStrength < 1 {
 		renderSettings(w, r, "Initial tower strength must be at least 1.")
@@ -76,12 +171,18 @@ func SettingsPostHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	eventSettings.InitialTowerStrength = initialTowerStrength
 
-	err := db.SaveEventSettings(eventSettings)
+	err = db.SaveEventSettings(eventSettings)
 	if err != nil {
 		handleWebErr(w, err)
 		return
 	}
 
+	// Apply the new match timing right away unless there is a match in progress, in which case it will be picked up
+	// when the next match is loaded.
+	if mainArena.MatchState == PRE_MATCH {
+		mainArena.UpdateMatchTiming()
+	}
+
 	// Set up the light controller connections again in case the address changed.
 	err = mainArena.lights.SetupConnections()
 	if err != nil {
@@ -89,9 +190,24 @@ func SettingsPostHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Rebind the driver station listeners if their settings changed or if they failed to bind last time.
+	dsListenerStatus := dsListeners.Status()
+	if getDsNetworkConfig() != previousDsNetworkConfig || !dsListenerStatus.TcpBound || !dsListenerStatus.UdpBound {
+		RestartDsListeners()
+	}
+
 	http.Redirect(w, r, "/setup/settings", 302)
 }
 
+// Parses a port number from the settings form, falling back to the given default if it is left blank.
+func parsePortSetting(value string, defaultPort int) (int, bool) {
+	if value == "" {
+		return defaultPort, true
+	}
+	port, err := strconv.Atoi(value)
+	return port, err == nil && port > 0 && port <= 65535
+}
+
 // Sends a copy of the event database file to the client as a download.
 func SaveDbHandler(w http.ResponseWriter, r *http.Request) {
 	if !UserIsAdmin(w, r) {
@@ -182,6 +298,13 @@ func ClearDbHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Set aside the logs of the matches being cleared so that they don't show up under new ones of the same name.
+	err = ArchiveMatchLogs("pre_clear")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
 	err = db.TruncateMatches()
 	if err != nil {
 		handleWebErr(w, err)
@@ -192,6 +315,11 @@ func ClearDbHandler(w http.ResponseWriter, r *http.Request) {
 		handleWebErr(w, err)
 		return
 	}
+	err = db.TruncateMatchResultHistory()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
 	err = db.TruncateRankings()
 	if err != nil {
 		handleWebErr(w, err)
@@ -202,6 +330,26 @@ func ClearDbHandler(w http.ResponseWriter, r *http.Request) {
 		handleWebErr(w, err)
 		return
 	}
+	err = db.TruncateAllianceTimeouts()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	err = db.TruncateScheduleBlocks()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	err = db.TruncateConnectionEvents()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	err = db.TruncateMatchEvents()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
 	http.Redirect(w, r, "/setup/settings", 302)
 }
 
@@ -213,8 +361,12 @@ func renderSettings(w http.ResponseWriter, r *http.Request, errorMessage string)
 	}
 	data := struct {
 		*EventSettings
-		ErrorMessage string
-	}{eventSettings, errorMessage}
+		Games                 map[string]Game
+		EliminationBrackets   map[string]EliminationBracket
+		RoundRobinPointsRules map[string]string
+		DsProtocols           map[string]string
+		ErrorMessage          string
+	}{eventSettings, games, eliminationBrackets, roundRobinPointsRules, dsProtocols, errorMessage}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
 		handleWebErr(w, err)
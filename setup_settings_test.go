@@ -11,6 +11,8 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -22,6 +24,7 @@ func TestSetupSettings(t *testing.T) {
 	assert.Nil(t, err)
 	defer db.Close()
 	eventSettings, _ = db.GetEventSettings()
+	mainArena.Setup()
 
 	// Check the default setting values.
 	recorder := getHttpResponse("/setup/settings")
@@ -34,8 +37,10 @@ func TestSetupSettings(t *testing.T) {
 
 	// Change the settings and check the response.
 	recorder = postHttpResponse("/setup/settings", "name=Chezy Champs&code=CC&displayBackgroundColor=#ff00ff&"+
-		"numElimAlliances=16&tbaPublishingEnabled=on&tbaEventCode=2014cc&tbaSecretId=secretId&tbaSecret=tbasec&"+
-		"initialTowerStrength=9001")
+		"elimType=single&numElimAlliances=16&autoDurationSec=20&pauseDurationSec=0&teleopDurationSec=100&"+
+		"endgameTimeLeftSec=20&fieldResetWarningSec=60&fieldResetLimitSec=90&tbaPublishingEnabled=on&"+
+		"tbaEventCode=2014cc&tbaSecretId=secretId&tbaSecret=tbasec&"+
+		"dsProtocol=tagged&game=stronghold&initialTowerStrength=9001")
 	assert.Equal(t, 302, recorder.Code)
 	recorder = getHttpResponse("/setup/settings")
 	assert.Contains(t, recorder.Body.String(), "Chezy Champs")
@@ -47,6 +52,41 @@ func TestSetupSettings(t *testing.T) {
 	assert.Contains(t, recorder.Body.String(), "secretId")
 	assert.Contains(t, recorder.Body.String(), "tbasec")
 	assert.Contains(t, recorder.Body.String(), "9001")
+	assert.Equal(t, MatchTiming{20, 0, 100, 20}, mainArena.matchTiming)
+	assert.Equal(t, 60, eventSettings.FieldResetWarningSec)
+	assert.Equal(t, 90, eventSettings.FieldResetLimitSec)
+	assert.Equal(t, "single", eventSettings.ElimType)
+	assert.Equal(t, "tagged", eventSettings.DsProtocol)
+	assert.Equal(t, "10.0.100.5", eventSettings.DsListenAddress)
+	assert.Equal(t, 1750, eventSettings.DsTcpListenPort)
+}
+
+func TestSetupSettingsDsNetwork(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+	mainArena.Setup()
+	defer dsListeners.Restart(DsNetworkConfig{"127.0.0.1", "", 1750, 1160, 1121})
+
+	recorder := postHttpResponse("/setup/settings", "elimType=single&numElimAlliances=8&displayBackgroundColor=#000&"+
+		"autoDurationSec=15&pauseDurationSec=2&teleopDurationSec=135&endgameTimeLeftSec=30&"+
+		"fieldResetWarningSec=120&fieldResetLimitSec=180&dsProtocol=2015&dsTcpListenPort=65536")
+	assert.Contains(t, recorder.Body.String(), "Driver station TCP port must be between 1 and 65535")
+
+	// Changing the settings should rebind the listeners.
+	recorder = postHttpResponse("/setup/settings", "elimType=single&numElimAlliances=8&displayBackgroundColor=#000&"+
+		"autoDurationSec=15&pauseDurationSec=2&teleopDurationSec=135&endgameTimeLeftSec=30&"+
+		"fieldResetWarningSec=120&fieldResetLimitSec=180&dsProtocol=2015&game=stronghold&initialTowerStrength=10&"+
+		"dsListenAddress=127.0.0.1&dsTcpListenPort=17503&dsUdpReceivePort=11603&dsUdpSendPort=")
+	assert.Equal(t, 302, recorder.Code)
+	assert.Equal(t, 1121, eventSettings.DsUdpSendPort)
+	assert.Equal(t, DsListenerStatus{"127.0.0.1", 17503, 11603, true, true, ""}, dsListeners.Status())
+	recorder = getHttpResponse("/setup/settings")
+	assert.Contains(t, recorder.Body.String(), "17503")
 }
 
 func TestSetupSettingsInvalidValues(t *testing.T) {
@@ -63,8 +103,83 @@ func TestSetupSettingsInvalidValues(t *testing.T) {
 	assert.Contains(t, recorder.Body.String(), "must be a valid hex color value")
 
 	// Invalid number of alliances.
-	recorder = postHttpResponse("/setup/settings", "numAlliances=1&displayBackgroundColor=#000")
+	recorder = postHttpResponse("/setup/settings", "elimType=single&numAlliances=1&displayBackgroundColor=#000")
 	assert.Contains(t, recorder.Body.String(), "must be between 2 and 16")
+	recorder = postHttpResponse("/setup/settings", "elimType=double&numElimAlliances=6&displayBackgroundColor=#000")
+	assert.Contains(t, recorder.Body.String(), "must be 8 for a double-elimination bracket")
+
+	recorder = postHttpResponse("/setup/settings", "elimType=roundrobin&numElimAlliances=4&"+
+		"roundRobinPointsRule=blorpy&displayBackgroundColor=#000")
+	assert.Contains(t, recorder.Body.String(), "Invalid round-robin points rule selected")
+
+	// Invalid playoff format.
+	recorder = postHttpResponse("/setup/settings", "elimType=blorpy&numElimAlliances=8&displayBackgroundColor=#000")
+	assert.Contains(t, recorder.Body.String(), "Invalid playoff format selected")
+
+	// Invalid match timing.
+	recorder = postHttpResponse("/setup/settings", "elimType=single&numElimAlliances=8&displayBackgroundColor=#000&"+
+		"autoDurationSec=15&pauseDurationSec=-1&teleopDurationSec=135&endgameTimeLeftSec=30")
+	assert.Contains(t, recorder.Body.String(), "Pause duration must be a non-negative number of seconds")
+	recorder = postHttpResponse("/setup/settings", "elimType=single&numElimAlliances=8&displayBackgroundColor=#000&"+
+		"autoDurationSec=15&pauseDurationSec=2&teleopDurationSec=135&endgameTimeLeftSec=136")
+	assert.Contains(t, recorder.Body.String(), "Endgame warning must be between")
+	recorder = postHttpResponse("/setup/settings", "elimType=single&numElimAlliances=8&displayBackgroundColor=#000&"+
+		"autoDurationSec=15&pauseDurationSec=2&teleopDurationSec=135&endgameTimeLeftSec=30&"+
+		"fieldResetWarningSec=120&fieldResetLimitSec=60")
+	assert.Contains(t, recorder.Body.String(), "Field reset limit must be no less than")
+
+	// Invalid driver station protocol.
+	recorder = postHttpResponse("/setup/settings", "elimType=single&numElimAlliances=8&displayBackgroundColor=#000&"+
+		"autoDurationSec=15&pauseDurationSec=2&teleopDurationSec=135&endgameTimeLeftSec=30&"+
+		"fieldResetWarningSec=120&fieldResetLimitSec=180&dsProtocol=blorpy")
+	assert.Contains(t, recorder.Body.String(), "Invalid driver station protocol selected")
+
+	// Invalid game.
+	recorder = postHttpResponse("/setup/settings", "elimType=single&numElimAlliances=8&displayBackgroundColor=#000&"+
+		"autoDurationSec=15&pauseDurationSec=2&teleopDurationSec=135&endgameTimeLeftSec=30&"+
+		"fieldResetWarningSec=120&fieldResetLimitSec=180&dsProtocol=2015&game=blorpy")
+	assert.Contains(t, recorder.Body.String(), "Invalid game selected")
+}
+
+func TestSetupSettingsChangeGame(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+	games["othergame"] = &otherGame{name: "Other Game"}
+	defer delete(games, "othergame")
+	settingsForm := "elimType=single&numElimAlliances=8&displayBackgroundColor=#000&autoDurationSec=15&" +
+		"pauseDurationSec=2&teleopDurationSec=135&endgameTimeLeftSec=30&fieldResetWarningSec=120&" +
+		"fieldResetLimitSec=180&dsProtocol=2015&initialTowerStrength=10&game="
+
+	// The game can't be changed once there are results that were scored under it.
+	db.CreateMatchResult(&MatchResult{MatchId: 1})
+	recorder := postHttpResponse("/setup/settings", settingsForm+"othergame")
+	assert.Contains(t, recorder.Body.String(), "The game cannot be changed once match results exist")
+	assert.Equal(t, "stronghold", eventSettings.Game)
+
+	// Saving the settings without changing the game is still allowed.
+	recorder = postHttpResponse("/setup/settings", settingsForm+"stronghold")
+	assert.Equal(t, 302, recorder.Code)
+
+	// Once the results are cleared, the game can be changed.
+	db.TruncateMatchResults()
+	recorder = postHttpResponse("/setup/settings", settingsForm+"othergame")
+	assert.Equal(t, 302, recorder.Code)
+	assert.Equal(t, "othergame", eventSettings.Game)
+}
+
+// A second game for exercising game changes; it's distinguished from Stronghold only by its name.
+type otherGame struct {
+	Stronghold
+	name string
+}
+
+func (game *otherGame) Name() string {
+	return game.name
 }
 
 func TestSetupSettingsClearDb(t *testing.T) {
@@ -81,6 +196,9 @@ func TestSetupSettingsClearDb(t *testing.T) {
 	db.CreateMatchResult(new(MatchResult))
 	db.CreateRanking(new(Ranking))
 	db.CreateAllianceTeam(new(AllianceTeam))
+	db.CreateAllianceTimeout(&AllianceTimeout{AllianceId: 1})
+	writeTestMatchLog(t)
+	defer removeTestMatchLog()
 	recorder := postHttpResponse("/setup/db/clear", "")
 	assert.Equal(t, 302, recorder.Code)
 
@@ -94,6 +212,14 @@ func TestSetupSettingsClearDb(t *testing.T) {
 	assert.Empty(t, rankings)
 	alliances, _ := db.GetAllAlliances()
 	assert.Empty(t, alliances)
+	allianceTimeout, _ := db.GetAllianceTimeoutByAllianceId(1)
+	assert.Nil(t, allianceTimeout)
+	matchLogGroups, _ := ListMatchLogs()
+	assert.Empty(t, matchLogGroups)
+	archivedFilenames, _ := filepath.Glob(filepath.Join(logsArchiveDir, "*_pre_clear", testMatchLogFilename))
+	if assert.Equal(t, 1, len(archivedFilenames)) {
+		os.RemoveAll(filepath.Dir(archivedFilenames[0]))
+	}
 }
 
 func TestSetupSettingsBackupRestoreDb(t *testing.T) {
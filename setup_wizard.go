@@ -0,0 +1,179 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for the setup wizard, which walks the scorekeeper through each phase of running an event.
+
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+)
+
+// One phase of running the event, along with whether it has been completed and any problems that stand in the
+// way of completing it.
+type SetupWizardStep struct {
+	Title       string
+	Description string
+	Url         string
+	Complete    bool
+	Warnings    []string
+}
+
+// Examines the current state of the event to determine the status of each step of the wizard.
+func (database *Database) GetSetupWizardSteps() ([]*SetupWizardStep, error) {
+	teams, err := database.GetAllTeams()
+	if err != nil {
+		return nil, err
+	}
+	practiceMatches, err := database.GetMatchesByType("practice")
+	if err != nil {
+		return nil, err
+	}
+	qualificationMatches, err := database.GetMatchesByType("qualification")
+	if err != nil {
+		return nil, err
+	}
+	alliances, err := database.GetAllAlliances()
+	if err != nil {
+		return nil, err
+	}
+
+	settingsStep := &SetupWizardStep{Title: "Configure event settings",
+		Description: "Set the event name, playoff format and the addresses of the field hardware.",
+		Url:         "/setup/settings", Complete: eventSettings.Name != "Untitled Event"}
+	if _, ok := currentGame().(*Stronghold); ok {
+		if eventSettings.RedDefenseLightsAddress == "" {
+			settingsStep.Warnings = append(settingsStep.Warnings, "The red defense lights address is not set.")
+		}
+		if eventSettings.BlueDefenseLightsAddress == "" {
+			settingsStep.Warnings = append(settingsStep.Warnings, "The blue defense lights address is not set.")
+		}
+	}
+	if eventSettings.NetworkSecurityEnabled {
+		if eventSettings.ApAddress == "" {
+			settingsStep.Warnings = append(settingsStep.Warnings,
+				"Network security is enabled but the access point address is not set.")
+		}
+		if eventSettings.SwitchAddress == "" {
+			settingsStep.Warnings = append(settingsStep.Warnings,
+				"Network security is enabled but the switch address is not set.")
+		}
+	}
+	if eventSettings.TbaPublishingEnabled && (eventSettings.TbaEventCode == "" ||
+		eventSettings.TbaSecretId == "" || eventSettings.TbaSecret == "") {
+		settingsStep.Warnings = append(settingsStep.Warnings,
+			"TBA publishing is enabled but the event code or secret is not set.")
+	}
+	if err := currentEliminationBracket().ValidateNumAlliances(eventSettings.NumElimAlliances); err != nil {
+		settingsStep.Warnings = append(settingsStep.Warnings, err.Error())
+	}
+
+	teamsStep := &SetupWizardStep{Title: "Load teams", Description: "Enter the list of teams attending the event.",
+		Url: "/setup/teams", Complete: len(teams) > 0}
+	if len(teams) > 0 && len(teams) < teamsPerMatch {
+		teamsStep.Warnings = append(teamsStep.Warnings, fmt.Sprintf(
+			"Only %d teams are loaded; at least %d are needed to build a schedule.", len(teams), teamsPerMatch))
+	}
+
+	wpaKeysStep := &SetupWizardStep{Title: "Generate WPA keys", Url: "/setup/teams", Complete: true}
+	if eventSettings.NetworkSecurityEnabled {
+		wpaKeysStep.Description = "Generate the keys that secure each team's robot network."
+		numMissingKeys := 0
+		for _, team := range teams {
+			if len(team.WpaKey) < 8 || len(team.WpaKey) > 63 {
+				numMissingKeys++
+			}
+		}
+		wpaKeysStep.Complete = len(teams) > 0 && numMissingKeys == 0
+		if len(teams) == 0 {
+			wpaKeysStep.Warnings = append(wpaKeysStep.Warnings, "No teams have been loaded.")
+		} else if numMissingKeys > 0 {
+			wpaKeysStep.Warnings = append(wpaKeysStep.Warnings,
+				fmt.Sprintf("%d teams are missing a valid WPA key.", numMissingKeys))
+		}
+	} else {
+		wpaKeysStep.Description = "Not needed since network security is disabled."
+	}
+
+	practiceStep := &SetupWizardStep{Title: "Save practice schedule",
+		Description: "Generate and save the practice match schedule.", Url: "/setup/schedule",
+		Complete: len(practiceMatches) > 0}
+	qualificationStep := &SetupWizardStep{Title: "Save qualification schedule",
+		Description: "Generate and save the qualification match schedule.", Url: "/setup/schedule",
+		Complete: len(qualificationMatches) > 0}
+	if len(teams) == 0 {
+		practiceStep.Warnings = append(practiceStep.Warnings, "No teams have been loaded.")
+		qualificationStep.Warnings = append(qualificationStep.Warnings, "No teams have been loaded.")
+	}
+
+	numQualificationsLeft := 0
+	for _, match := range qualificationMatches {
+		if match.Status != "complete" {
+			numQualificationsLeft++
+		}
+	}
+	qualificationsPlayedStep := &SetupWizardStep{Title: "Play qualification matches",
+		Description: "Run each qualification match and commit its results.", Url: "/match_play",
+		Complete: len(qualificationMatches) > 0 && numQualificationsLeft == 0}
+	if len(qualificationMatches) == 0 {
+		qualificationsPlayedStep.Warnings = append(qualificationsPlayedStep.Warnings,
+			"The qualification schedule has not been saved.")
+	} else if numQualificationsLeft > 0 {
+		qualificationsPlayedStep.Warnings = append(qualificationsPlayedStep.Warnings,
+			fmt.Sprintf("%d qualification matches remain to be played.", numQualificationsLeft))
+	}
+
+	alliancesStep := &SetupWizardStep{Title: "Finalize alliances",
+		Description: "Conduct alliance selection and finalize the results to create the playoff matches.",
+		Url:         "/setup/alliance_selection", Complete: len(alliances) > 0}
+	if !alliancesStep.Complete {
+		if !qualificationsPlayedStep.Complete {
+			alliancesStep.Warnings = append(alliancesStep.Warnings, "Qualification matches are not complete.")
+		}
+		if len(cachedAlliances) > 0 {
+			alliancesStep.Warnings = append(alliancesStep.Warnings,
+				"Alliance selection is in progress but has not been finalized.")
+		}
+	}
+
+	return []*SetupWizardStep{settingsStep, teamsStep, wpaKeysStep, practiceStep, qualificationStep,
+		qualificationsPlayedStep, alliancesStep}, nil
+}
+
+// Shows the setup wizard page.
+func SetupWizardGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !UserIsAdmin(w, r) {
+		return
+	}
+
+	steps, err := db.GetSetupWizardSteps()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	currentStep := len(steps)
+	for i, step := range steps {
+		if !step.Complete {
+			currentStep = i
+			break
+		}
+	}
+
+	template, err := template.ParseFiles("templates/setup_wizard.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*EventSettings
+		Steps       []*SetupWizardStep
+		CurrentStep int
+	}{eventSettings, steps, currentStep}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSetupWizardSteps(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+
+	steps, err := db.GetSetupWizardSteps()
+	assert.Nil(t, err)
+	if assert.Equal(t, 7, len(steps)) {
+		assert.False(t, steps[0].Complete)
+		assert.Contains(t, steps[0].Warnings, "The red defense lights address is not set.")
+		assert.False(t, steps[1].Complete)
+		assert.True(t, steps[2].Complete)
+		assert.Contains(t, steps[3].Warnings, "No teams have been loaded.")
+		assert.Contains(t, steps[5].Warnings, "The qualification schedule has not been saved.")
+		assert.False(t, steps[6].Complete)
+	}
+
+	eventSettings.Name = "Chezy Champs"
+	eventSettings.RedDefenseLightsAddress = "10.0.0.10"
+	eventSettings.BlueDefenseLightsAddress = "10.0.0.11"
+	eventSettings.NetworkSecurityEnabled = true
+	defer func() { eventSettings.NetworkSecurityEnabled = false }()
+	db.CreateTeam(&Team{Id: 254, WpaKey: "12345678"})
+	db.CreateTeam(&Team{Id: 1114})
+	steps, _ = db.GetSetupWizardSteps()
+	assert.True(t, steps[0].Complete)
+	assert.NotContains(t, steps[0].Warnings, "The red defense lights address is not set.")
+	assert.Contains(t, steps[0].Warnings, "Network security is enabled but the access point address is not set.")
+	assert.True(t, steps[1].Complete)
+	assert.Contains(t, steps[1].Warnings, "Only 2 teams are loaded; at least 6 are needed to build a schedule.")
+	assert.False(t, steps[2].Complete)
+	assert.Contains(t, steps[2].Warnings, "1 teams are missing a valid WPA key.")
+
+	db.CreateMatch(&Match{Type: "practice", DisplayName: "1"})
+	db.CreateMatch(&Match{Type: "qualification", DisplayName: "1", Status: "complete"})
+	db.CreateMatch(&Match{Type: "qualification", DisplayName: "2"})
+	steps, _ = db.GetSetupWizardSteps()
+	assert.True(t, steps[3].Complete)
+	assert.True(t, steps[4].Complete)
+	assert.False(t, steps[5].Complete)
+	assert.Contains(t, steps[5].Warnings, "1 qualification matches remain to be played.")
+	assert.Contains(t, steps[6].Warnings, "Qualification matches are not complete.")
+
+	createTestAlliances(db, 2)
+	steps, _ = db.GetSetupWizardSteps()
+	assert.True(t, steps[6].Complete)
+	assert.Empty(t, steps[6].Warnings)
+}
+
+func TestSetupWizard(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+
+	recorder := getHttpResponse("/setup/wizard")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Configure event settings")
+	assert.Contains(t, recorder.Body.String(), "Finalize alliances")
+	assert.Contains(t, recorder.Body.String(), "Next Step")
+	assert.Contains(t, recorder.Body.String(), "/setup/alliance_selection")
+}
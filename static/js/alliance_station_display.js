@@ -111,6 +111,15 @@ var handleMatchTime = function(data) {
   });
 };
 
+// Handles a websocket message to update the timeout countdown.
+var handleTimeout = function(data) {
+  if (data.Type == "") {
+    $("#disabled").text("DISABLED");
+  } else {
+    $("#disabled").text(getTimeoutText(data));
+  }
+};
+
 // Handles a websocket message to update the match score.
 var handleRealtimeScore = function(data) {
   $("#redScore").text(data.RedScoreFields.Score);
@@ -132,6 +141,7 @@ $(function() {
     setMatch: function(event) { handleSetMatch(event.data); },
     status: function(event) { handleStatus(event.data); },
     matchTiming: function(event) { handleMatchTiming(event.data); },
+    timeout: function(event) { handleTimeout(event.data); },
     matchTime: function(event) { handleMatchTime(event.data); },
     realtimeScore: function(event) { handleRealtimeScore(event.data); }
   });
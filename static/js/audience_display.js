@@ -8,6 +8,7 @@ var websocket;
 var transitionMap;
 var currentScreen = "blank";
 var allianceSelectionTemplate = Handlebars.compile($("#allianceSelectionTemplate").html());
+var bracketTemplate = Handlebars.compile($("#bracketTemplate").html());
 
 // Handles a websocket message to change which screen is displayed.
 var handleSetAudienceDisplay = function(targetScreen) {
@@ -50,6 +51,15 @@ var handleMatchTime = function(data) {
   });
 };
 
+// Handles a websocket message to show or hide the timeout countdown overlay.
+var handleTimeout = function(data) {
+  if (data.Type == "") {
+    $("#timeoutOverlay").fadeOut(300);
+  } else {
+    $("#timeoutOverlay").attr("data-type", data.Type).text(getTimeoutText(data)).fadeIn(300);
+  }
+};
+
 // Handles a websocket message to update the match score.
 var handleRealtimeScore = function(data) {
   $("#redScoreNumber").text(data.RedScoreFields.Score);
@@ -109,6 +119,17 @@ var handleAllianceSelection = function(alliances) {
   }
 };
 
+// Handles a websocket message to update the playoff bracket screen.
+var handleBracket = function(bracket) {
+  $.each(bracket.Series, function(k, v) {
+    v.RedLabel = v.RedAllianceId ? "A" + v.RedAllianceId : "TBD";
+    v.BlueLabel = v.BlueAllianceId ? "A" + v.BlueAllianceId : "TBD";
+    v.RedWinner = v.WinnerAllianceId && v.WinnerAllianceId == v.RedAllianceId;
+    v.BlueWinner = v.WinnerAllianceId && v.WinnerAllianceId == v.BlueAllianceId;
+  });
+  $("#bracket").html(bracketTemplate(bracket));
+};
+
 // Handles a websocket message to populate and/or show/hide a lower third.
 var handleLowerThird = function(data) {
   if (data.BottomText == "") {
@@ -256,6 +277,15 @@ var transitionAllianceSelectionToBlank = function(callback) {
   $('#allianceSelectionCentering').transition({queue: false, right: "-60em"}, 500, "ease", callback);
 };
 
+var transitionBlankToBracket = function(callback) {
+  $('#bracketCentering').css("right","-60em").show();
+  $('#bracketCentering').transition({queue: false, right: "3em"}, 500, "ease", callback);
+};
+
+var transitionBracketToBlank = function(callback) {
+  $('#bracketCentering').transition({queue: false, right: "-60em"}, 500, "ease", callback);
+};
+
 var transitionBlankToLowerThird = function(callback) {
   $("#lowerThird").show();
   $("#lowerThird").transition({queue: false, left: "150px"}, 750, "ease", callback);
@@ -368,11 +398,13 @@ $(function() {
     setAudienceDisplay: function(event) { handleSetAudienceDisplay(event.data); },
     setMatch: function(event) { handleSetMatch(event.data); },
     matchTiming: function(event) { handleMatchTiming(event.data); },
+    timeout: function(event) { handleTimeout(event.data); },
     matchTime: function(event) { handleMatchTime(event.data); },
     realtimeScore: function(event) { handleRealtimeScore(event.data); },
     setFinalScore: function(event) { handleSetFinalScore(event.data); },
     playSound: function(event) { handlePlaySound(event.data); },
     allianceSelection: function(event) { handleAllianceSelection(event.data); },
+    bracket: function(event) { handleBracket(event.data); },
     lowerThird: function(event) { handleLowerThird(event.data); }
   });
 
@@ -388,6 +420,7 @@ $(function() {
       logo: transitionBlankToLogo,
       sponsor: transitionBlankToSponsor,
       allianceSelection: transitionBlankToAllianceSelection,
+      bracket: transitionBlankToBracket,
       lowerThird: transitionBlankToLowerThird
     },
     intro: {
@@ -416,6 +449,9 @@ $(function() {
     allianceSelection: {
       blank: transitionAllianceSelectionToBlank
     },
+    bracket: {
+      blank: transitionBracketToBlank
+    },
     lowerThird: {
       blank: transitionLowerThirdToBlank
     }
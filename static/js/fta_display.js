@@ -6,14 +6,34 @@
 
 var websocket;
 
+// Emergency stops the robot in an alliance station until the match is reset. This goes through the API rather than
+// the websocket, since only the API requires the user to be authenticated.
+var emergencyStop = function(station) {
+  postStationStop(station, "emergency_stop");
+};
+
+// Disables the robot in an alliance station for the rest of the autonomous period.
+var aStop = function(station) {
+  postStationStop(station, "a_stop");
+};
+
+// Posts the given stop command for an alliance station and reports any failure to the user.
+var postStationStop = function(station, command) {
+  $.post("/api/stations/" + station + "/" + command).fail(function(response) {
+    alert(response.responseText);
+  });
+};
+
 // Handles a websocket message to update the team connection status.
 var handleStatus = function(data) {
   // Update the team status view.
   $.each(data.AllianceStations, function(station, stationStatus) {
     if (stationStatus.Team) {
       $("#status" + station + " .team").text(stationStatus.Team.Id);
+      $("#diagnostics" + station + " .team").text(stationStatus.Team.Id);
     } else {
       $("#status" + station + " .team").text("");
+      $("#diagnostics" + station + " .team").text("");
     }
 
     if (stationStatus.DsConn) {
@@ -37,6 +57,8 @@ var handleStatus = function(data) {
       $("#status" + station + " .trip-time").text(dsConn.DsRobotTripTimeMs.toFixed(1) + "ms");
       $("#status" + station + " .packet-loss").attr("data-status-ok", true);
       $("#status" + station + " .packet-loss").text(dsConn.MissedPacketCount);
+      $("#diagnostics" + station + " .ds-version").text(dsConn.DsVersion);
+      handleDsMessages(station, dsConn.RecentDsMessages);
     } else {
       $("#status" + station + " .ds-status").attr("data-status-ok", "");
       $("#status" + station + " .ds-status").text("");
@@ -48,11 +70,16 @@ var handleStatus = function(data) {
       $("#status" + station + " .trip-time").text("");
       $("#status" + station + " .packet-loss").attr("data-status-ok", "");
       $("#status" + station + " .packet-loss").text("");
+      $("#diagnostics" + station + " .ds-version").text("");
+      handleDsMessages(station, []);
     }
 
     if (stationStatus.EmergencyStop) {
       $("#status" + station + " .bypass-status-fta").attr("data-status-ok", false);
       $("#status" + station + " .bypass-status-fta").text("ES");
+    } else if (stationStatus.AStop) {
+      $("#status" + station + " .bypass-status-fta").attr("data-status-ok", false);
+      $("#status" + station + " .bypass-status-fta").text("AS");
     } else if (stationStatus.Bypass) {
       $("#status" + station + " .bypass-status-fta").attr("data-status-ok", false);
       $("#status" + station + " .bypass-status-fta").text("B");
@@ -63,12 +90,33 @@ var handleStatus = function(data) {
   });
 };
 
+// Lists the most recent console messages from the given station's driver station, newest first.
+var handleDsMessages = function(station, messages) {
+  var messagesCell = $("#diagnostics" + station + " .ds-messages");
+  messagesCell.empty();
+  $.each((messages || []).slice().reverse(), function(i, message) {
+    var levelClass = {error: "text-danger", warning: "text-warning"}[message.Level] || "";
+    messagesCell.append($("<div>").addClass(levelClass).text(message.Text));
+  });
+};
+
+// Handles a websocket message to update the status of the sockets that driver stations connect to.
+var handleDsListenerStatus = function(data) {
+  var bound = data.TcpBound && data.UdpBound;
+  $("#dsListenerStatus").attr("class", "label " + (bound ? "label-success" : "label-danger"));
+  $("#dsListenerStatus").text((data.Address || "*") + " TCP " + data.TcpPort + (data.TcpBound ? "" : " (unbound)") +
+      " / UDP " + data.UdpPort + (data.UdpBound ? "" : " (unbound)"));
+  $("#dsListenerError").text(data.Error);
+};
+
 $(function() {
   // Activate tooltips above the status headers.
   $("[data-toggle=tooltip]").tooltip({"placement": "top"});
 
   // Set up the websocket back to the server.
   websocket = new CheesyWebsocket("/displays/fta/websocket", {
-    status: function(event) { handleStatus(event.data); }
+    status: function(event) { handleStatus(event.data); },
+    fieldResetTimer: function(event) { handleFieldResetTimer(event.data); },
+    dsListenerStatus: function(event) { handleDsListenerStatus(event.data); }
   });
 });
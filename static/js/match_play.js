@@ -5,6 +5,9 @@
 
 var websocket;
 var scoreIsReady;
+var logEntries = [];
+var maxLogEntries = 200;
+var logLevels = { info: 0, warning: 1, error: 2 };
 
 // Sends a websocket message to load a team into an alliance station.
 var substituteTeam = function(team, position) {
@@ -16,6 +19,16 @@ var toggleBypass = function(station) {
   websocket.send("toggleBypass", station);
 };
 
+// Sends a websocket message to emergency stop the robot in an alliance station until the match is reset.
+var emergencyStop = function(station) {
+  websocket.send("emergencyStop", station);
+};
+
+// Sends a websocket message to disable the robot in an alliance station for the rest of the autonomous period.
+var aStop = function(station) {
+  websocket.send("aStop", station);
+};
+
 // Sends a websocket message to start the match.
 var startMatch = function() {
   websocket.send("startMatch", { muteMatchSounds: $("#muteMatchSounds").prop("checked") });
@@ -46,6 +59,21 @@ var setAllianceStationDisplay = function() {
   websocket.send("setAllianceStationDisplay", $("input[name=allianceStationDisplay]:checked").val());
 };
 
+// Sends a websocket message to start a timeout for the given alliance.
+var startAllianceTimeout = function(alliance) {
+  websocket.send("startAllianceTimeout", alliance);
+};
+
+// Sends a websocket message to start a field timeout of the entered length.
+var startFieldTimeout = function() {
+  websocket.send("startFieldTimeout", { durationSec: Math.round(parseFloat($("#fieldTimeoutMin").val()) * 60) });
+};
+
+// Sends a websocket message to end the timeout in progress.
+var endTimeout = function() {
+  websocket.send("clearTimeout");
+};
+
 var confirmCommit = function(isReplay) {
   if (isReplay || !scoreIsReady) {
     // Show the appropriate message(s) in the confirmation dialog.
@@ -90,6 +118,9 @@ var handleStatus = function(data) {
     if (stationStatus.EmergencyStop) {
       $("#status" + station + " .bypass-status").attr("data-status-ok", false);
       $("#status" + station + " .bypass-status").text("ES");
+    } else if (stationStatus.AStop) {
+      $("#status" + station + " .bypass-status").attr("data-status-ok", false);
+      $("#status" + station + " .bypass-status").text("AS");
     } else if (stationStatus.Bypass) {
       $("#status" + station + " .bypass-status").attr("data-status-ok", false);
       $("#status" + station + " .bypass-status").text("B");
@@ -157,12 +188,53 @@ var handleScoringStatus = function(data) {
   $("#blueScoreStatus").attr("data-ready", data.BlueScoreReady);
 };
 
+// Handles a websocket message to update the timeout countdown.
+var handleTimeout = function(data) {
+  if (data.Type == "") {
+    $("#timeoutStatus").attr("class", "label label-default").text("None");
+  } else {
+    $("#timeoutStatus").attr("class", "label label-warning").text(getTimeoutText(data));
+  }
+};
+
 // Handles a websocket message to update the alliance station display screen selector.
 var handleSetAllianceStationDisplay = function(data) {
   $("input[name=allianceStationDisplay]:checked").prop("checked", false);
   $("input[name=allianceStationDisplay][value=" + data + "]").prop("checked", true);
 };
 
+// Handles a websocket message containing the backlog of log entries, sent upon connection.
+var handleLogEntries = function(data) {
+  logEntries = data;
+  renderLogEntries();
+};
+
+// Handles a websocket message containing a single new log entry.
+var handleLogEntry = function(data) {
+  logEntries.push(data);
+  if (logEntries.length > maxLogEntries) {
+    logEntries.shift();
+  }
+  renderLogEntries();
+};
+
+// Redraws the log console, showing only the entries that pass the selected subsystem and severity filters.
+var renderLogEntries = function() {
+  var subsystem = $("#logSubsystem").val();
+  var minLevel = logLevels[$("#logLevel").val()];
+  var logConsole = $("#logConsole");
+  logConsole.empty();
+  $.each(logEntries, function(i, entry) {
+    if ((subsystem && entry.Subsystem != subsystem) || logLevels[entry.Level] < minLevel) {
+      return;
+    }
+    var line = $("<div>").addClass("log-" + entry.Level);
+    line.text(moment(entry.Time).format("hh:mm:ss") + " [" + entry.Subsystem + "] " + entry.Message);
+    logConsole.append(line);
+  });
+  logConsole.scrollTop(logConsole.prop("scrollHeight"));
+};
+
 $(function() {
   // Activate tooltips above the status headers.
   $("[data-toggle=tooltip]").tooltip({"placement": "top"});
@@ -171,10 +243,14 @@ $(function() {
   websocket = new CheesyWebsocket("/match_play/websocket", {
     status: function(event) { handleStatus(event.data); },
     matchTiming: function(event) { handleMatchTiming(event.data); },
+    timeout: function(event) { handleTimeout(event.data); },
+    fieldResetTimer: function(event) { handleFieldResetTimer(event.data); },
     matchTime: function(event) { handleMatchTime(event.data); },
     realtimeScore: function(event) { handleRealtimeScore(event.data); },
     setAudienceDisplay: function(event) { handleSetAudienceDisplay(event.data); },
     scoringStatus: function(event) { handleScoringStatus(event.data); },
-    setAllianceStationDisplay: function(event) { handleSetAllianceStationDisplay(event.data); }
+    setAllianceStationDisplay: function(event) { handleSetAllianceStationDisplay(event.data); },
+    logEntries: function(event) { handleLogEntries(event.data); },
+    logEntry: function(event) { handleLogEntry(event.data); }
   });
 });
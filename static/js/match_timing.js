@@ -61,3 +61,32 @@ var getCountdown = function(matchState, matchTimeSec) {
       return 0;
   }
 };
+
+// Returns a human-readable description of the given timeout status and its countdown, or an empty string if there is
+// no timeout in progress.
+var getTimeoutText = function(data) {
+  if (data.Type == "") {
+    return "";
+  }
+  var remainingString = String(data.RemainingSec % 60);
+  if (remainingString.length == 1) {
+    remainingString = "0" + remainingString;
+  }
+  remainingString = Math.floor(data.RemainingSec / 60) + ":" + remainingString;
+  var typeText = (data.Type == "field") ? "FIELD" : data.Type.toUpperCase() + " ALLIANCE";
+  return typeText + " TIMEOUT " + remainingString;
+};
+
+// Handles a websocket message to update the field reset timer, colouring it according to the configured thresholds.
+var handleFieldResetTimer = function(data) {
+  if (!data.Running) {
+    $("#fieldResetTimer").attr("data-status", "").text("--:--");
+    return;
+  }
+  var secondsString = String(data.ElapsedSec % 60);
+  if (secondsString.length == 1) {
+    secondsString = "0" + secondsString;
+  }
+  $("#fieldResetTimer").attr("data-status", data.Status).text(Math.floor(data.ElapsedSec / 60) + ":" +
+      secondsString);
+};
@@ -92,6 +92,7 @@ var commitMatch = function() {
 $(function() {
   // Set up the websocket back to the server.
   websocket = new CheesyWebsocket("/displays/referee/websocket", {
+    fieldResetTimer: function(event) { handleFieldResetTimer(event.data); }
   });
 
   clearFoul();
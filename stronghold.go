@@ -0,0 +1,172 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Scoring and ranking rules for the 2016 game, FIRST Stronghold.
+
+package main
+
+import (
+	"encoding/json"
+)
+
+type Stronghold struct{}
+
+// The Stronghold-specific scoring data for one alliance.
+type StrongholdScore struct {
+	AutoDefensesReached int
+	AutoDefensesCrossed [5]int
+	AutoLowGoals        int
+	AutoHighGoals       int
+	DefensesCrossed     [5]int
+	LowGoals            int
+	HighGoals           int
+	Challenges          int
+	Scales              int
+}
+
+// The Stronghold-specific fields of an alliance's score summary.
+type StrongholdScoreSummary struct {
+	DefensePoints        int
+	GoalPoints           int
+	ScaleChallengePoints int
+	BonusPoints          int
+	Breached             bool
+	Captured             bool
+	TowerStrength        int
+	DefensesStrength     [5]int
+}
+
+func (game *Stronghold) Name() string {
+	return "FIRST Stronghold (2016)"
+}
+
+func (game *Stronghold) NewScore() GameScore {
+	return StrongholdScore{}
+}
+
+func (game *Stronghold) DecodeScore(jsonData []byte) (GameScore, error) {
+	var score StrongholdScore
+	if err := json.Unmarshal(jsonData, &score); err != nil {
+		return nil, err
+	}
+	return score, nil
+}
+
+// Calculates and returns the summary fields used for ranking and display.
+func (game *Stronghold) ScoreSummary(score *Score, opponentFouls []Foul, matchType string) *ScoreSummary {
+	strongholdScore := getStrongholdScore(score)
+	summary := new(ScoreSummary)
+	var strongholdSummary StrongholdScoreSummary
+
+	// Calculate the number of crossings remaining on each defense before it is damaged.
+	for i := 0; i < 5; i++ {
+		strongholdSummary.DefensesStrength[i] = 2 - strongholdScore.AutoDefensesCrossed[i] -
+			strongholdScore.DefensesCrossed[i]
+	}
+
+	// Leave the score at zero if the team was disqualified.
+	if score.ElimDq {
+		summary.GameSummary = strongholdSummary
+		return summary
+	}
+
+	// Calculate autonomous score.
+	autoDefensePoints := 0
+	for _, defense := range strongholdScore.AutoDefensesCrossed {
+		autoDefensePoints += 10 * defense
+	}
+	autoGoalPoints := 5*strongholdScore.AutoLowGoals + 10*strongholdScore.AutoHighGoals
+	summary.AutoPoints = 2*strongholdScore.AutoDefensesReached + autoDefensePoints + autoGoalPoints
+
+	// Calculate teleop score.
+	teleopDefensePoints := 0
+	for _, defense := range strongholdScore.DefensesCrossed {
+		teleopDefensePoints += 5 * defense
+	}
+	strongholdSummary.ScaleChallengePoints = 5*strongholdScore.Challenges + 15*strongholdScore.Scales
+	teleopGoalPoints := 2*strongholdScore.LowGoals + 5*strongholdScore.HighGoals
+
+	// Calculate tower strength.
+	numTechFouls := 0
+	for _, foul := range score.Fouls {
+		if foul.IsTechnical {
+			numTechFouls++
+		}
+	}
+	strongholdSummary.TowerStrength = eventSettings.InitialTowerStrength + numTechFouls -
+		strongholdScore.AutoLowGoals - strongholdScore.AutoHighGoals - strongholdScore.LowGoals -
+		strongholdScore.HighGoals
+
+	// Calculate bonuses.
+	strongholdSummary.BonusPoints = 0
+	numDefensesDamaged := 0
+	for _, strength := range strongholdSummary.DefensesStrength {
+		if strength == 0 {
+			numDefensesDamaged++
+		}
+	}
+	if numDefensesDamaged >= 4 {
+		strongholdSummary.Breached = true
+		if matchType == "elimination" {
+			strongholdSummary.BonusPoints += 20
+		}
+	}
+	if strongholdScore.Challenges+strongholdScore.Scales == 3 && strongholdSummary.TowerStrength <= 0 {
+		strongholdSummary.Captured = true
+		if matchType == "elimination" {
+			strongholdSummary.BonusPoints += 25
+		}
+	}
+	summary.TeleopPoints = teleopDefensePoints + teleopGoalPoints + strongholdSummary.ScaleChallengePoints +
+		strongholdSummary.BonusPoints
+
+	summary.FoulPoints = 5 * len(opponentFouls)
+	strongholdSummary.DefensePoints = autoDefensePoints + teleopDefensePoints
+	strongholdSummary.GoalPoints = autoGoalPoints + teleopGoalPoints
+	summary.Score = summary.AutoPoints + summary.TeleopPoints + summary.FoulPoints
+	summary.GameSummary = strongholdSummary
+
+	return summary
+}
+
+// Awards two ranking points for a win and one for a tie, plus one each for a breach and a capture.
+func (game *Stronghold) RankingPoints(ownScore *ScoreSummary, opponentScore *ScoreSummary) int {
+	rankingPoints := 0
+	if ownScore.Score > opponentScore.Score {
+		rankingPoints += 2
+	} else if ownScore.Score == opponentScore.Score {
+		rankingPoints += 1
+	}
+	strongholdSummary := getStrongholdSummary(ownScore)
+	if strongholdSummary.Breached {
+		rankingPoints += 1
+	}
+	if strongholdSummary.Captured {
+		rankingPoints += 1
+	}
+	return rankingPoints
+}
+
+// Breaks ties using average auto, scale/challenge, goal, and defense points, in that order.
+func (game *Stronghold) Tiebreakers() []Tiebreaker {
+	return []Tiebreaker{{"AutoPoints", "Auto", "Auto"}, {"ScaleChallengePoints", "Scale/Challenge", "Scale/Chal."},
+		{"GoalPoints", "Goal", "Goal"}, {"DefensePoints", "Defense", "Defense"}}
+}
+
+func (game *Stronghold) TiebreakerPoints(ownScore *ScoreSummary) []int {
+	strongholdSummary := getStrongholdSummary(ownScore)
+	return []int{ownScore.AutoPoints, strongholdSummary.ScaleChallengePoints, strongholdSummary.GoalPoints,
+		strongholdSummary.DefensePoints}
+}
+
+// Returns the Stronghold scoring data held in the given score, or a blank one if it doesn't have any yet.
+func getStrongholdScore(score *Score) StrongholdScore {
+	strongholdScore, _ := score.GameScore.(StrongholdScore)
+	return strongholdScore
+}
+
+// Returns the Stronghold-specific fields of the given score summary.
+func getStrongholdSummary(summary *ScoreSummary) StrongholdScoreSummary {
+	strongholdSummary, _ := summary.GameSummary.(StrongholdScoreSummary)
+	return strongholdSummary
+}
@@ -65,19 +65,6 @@ type TbaScoreBreakdown struct {
 	TotalPoints            int    `json:"totalPoints"`
 }
 
-type TbaRanking struct {
-	TeamKey        string `json:"team_key"`
-	Rank           int    `json:"rank"`
-	RP             int    `json:"RP"`
-	Auto           int    `json:"Auto"`
-	ScaleChallenge int    `json:"Scale/Challenge"`
-	Goal           int    `json:"Goal"`
-	Defense        int    `json:"Defense"`
-	WinLossTie     string `json:"W-L-T"`
-	Dqs            int    `json:"dqs"`
-	Played         int    `json:"played"`
-}
-
 type TbaTeam struct {
 	Website    string `json:"website"`
 	Name       string `json:"name"`
@@ -103,6 +90,12 @@ type TbaAward struct {
 	EventName string
 }
 
+type TbaPublishedAward struct {
+	Name    string `json:"name_str"`
+	TeamKey string `json:"team_key,omitempty"`
+	Awardee string `json:"awardee,omitempty"`
+}
+
 type TbaEvent struct {
 	Name string `json:"name"`
 }
@@ -281,7 +274,7 @@ func PublishMatches() error {
 			"blue": blueAlliance}, scoreBreakdown, match.Time.Local().Format("3:04 PM"),
 			match.Time.UTC().Format("2006-01-02T15:04:05")}
 		if match.Type == "elimination" {
-			tbaMatches[i].CompLevel = map[int]string{1: "f", 2: "sf", 4: "qf", 8: "ef"}[match.ElimRound]
+			tbaMatches[i].CompLevel = currentEliminationBracket().TbaCompLevel(match.ElimRound)
 			tbaMatches[i].SetNumber = match.ElimGroup
 			tbaMatches[i].MatchNumber = match.ElimInstance
 		}
@@ -310,14 +303,23 @@ func PublishRankings() error {
 		return err
 	}
 
-	// Build a JSON object of TBA-format rankings.
-	breakdowns := []string{"RP", "Auto", "Scale/Challenge", "Goal", "Defense", "W-L-T"}
-	tbaRankings := make([]TbaRanking, len(rankings))
+	// Build a JSON object of TBA-format rankings, in which each breakdown is a field of the team's ranking.
+	tiebreakers := currentGame().Tiebreakers()
+	breakdowns := []string{"RP"}
+	for _, tiebreaker := range tiebreakers {
+		breakdowns = append(breakdowns, tiebreaker.Label)
+	}
+	breakdowns = append(breakdowns, "W-L-T")
+	tbaRankings := make([]map[string]interface{}, len(rankings))
 	for i, ranking := range rankings {
-		tbaRankings[i] = TbaRanking{getTbaTeam(ranking.TeamId), ranking.Rank, ranking.RankingPoints,
-			ranking.AutoPoints, ranking.ScaleChallengePoints, ranking.GoalPoints, ranking.DefensePoints,
-			fmt.Sprintf("%d-%d-%d", ranking.Wins, ranking.Losses, ranking.Ties), ranking.Disqualifications,
-			ranking.Played}
+		tbaRankings[i] = map[string]interface{}{"team_key": getTbaTeam(ranking.TeamId), "rank": ranking.Rank,
+			"RP": ranking.RankingPoints, "W-L-T": fmt.Sprintf("%d-%d-%d", ranking.Wins, ranking.Losses, ranking.Ties),
+			"dqs": ranking.Disqualifications, "played": ranking.Played}
+		for j, tiebreaker := range tiebreakers {
+			if j < len(ranking.Tiebreakers) {
+				tbaRankings[i][tiebreaker.Label] = ranking.Tiebreakers[j]
+			}
+		}
 	}
 	jsonBody, err := json.Marshal(map[string]interface{}{"breakdowns": breakdowns, "rankings": tbaRankings})
 	if err != nil {
@@ -367,6 +369,38 @@ func PublishAlliances() error {
 	return nil
 }
 
+// Uploads the awards to The Blue Alliance.
+func PublishAwards() error {
+	awards, err := db.GetAllAwards()
+	if err != nil {
+		return err
+	}
+
+	tbaAwards := make([]TbaPublishedAward, len(awards))
+	for i, award := range awards {
+		tbaAwards[i].Name = award.AwardName
+		if award.TeamId > 0 {
+			tbaAwards[i].TeamKey = getTbaTeam(award.TeamId)
+		}
+		tbaAwards[i].Awardee = award.PersonName
+	}
+	jsonBody, err := json.Marshal(tbaAwards)
+	if err != nil {
+		return err
+	}
+
+	resp, err := postTbaRequest("awards", "update", jsonBody)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != 200 {
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("Got status code %d from TBA: %s", resp.StatusCode, body)
+	}
+	return nil
+}
+
 // Clears out the existing match data on The Blue Alliance for the event.
 func DeletePublishedMatches() error {
 	resp, err := postTbaRequest("matches", "delete_all", []byte(eventSettings.TbaEventCode))
@@ -418,12 +452,12 @@ func getTbaRequest(url string) (*http.Response, error) {
 func createTbaScoringBreakdown(match *Match, matchResult *MatchResult, alliance string) *TbaScoreBreakdown {
 	tbaDefenseNames := map[string]string{"CDF": "A_ChevalDeFrise", "M": "B_Moat", "R": "B_Ramparts",
 		"RW": "D_RockWall", "RT": "D_RoughTerrain"}
-	var score *Score
+	var score StrongholdScore
 	var opponentScore *Score
 	var scoreSummary *ScoreSummary
 	var breakdown TbaScoreBreakdown
 	if alliance == "red" {
-		score = &matchResult.RedScore
+		score = getStrongholdScore(&matchResult.RedScore)
 		opponentScore = &matchResult.BlueScore
 		scoreSummary = matchResult.RedScoreSummary()
 		breakdown.Position2 = tbaDefenseNames[match.RedDefense2]
@@ -431,7 +465,7 @@ func createTbaScoringBreakdown(match *Match, matchResult *MatchResult, alliance
 		breakdown.Position4 = tbaDefenseNames[match.RedDefense4]
 		breakdown.Position5 = tbaDefenseNames[match.RedDefense5]
 	} else {
-		score = &matchResult.BlueScore
+		score = getStrongholdScore(&matchResult.BlueScore)
 		opponentScore = &matchResult.RedScore
 		scoreSummary = matchResult.BlueScoreSummary()
 		breakdown.Position2 = tbaDefenseNames[match.BlueDefense2]
@@ -439,10 +473,11 @@ func createTbaScoringBreakdown(match *Match, matchResult *MatchResult, alliance
 		breakdown.Position4 = tbaDefenseNames[match.BlueDefense4]
 		breakdown.Position5 = tbaDefenseNames[match.BlueDefense5]
 	}
+	strongholdSummary := getStrongholdSummary(scoreSummary)
 	breakdown.TeleopBouldersLow = score.LowGoals
 	breakdown.TeleopBouldersHigh = score.HighGoals
-	breakdown.TeleopTowerCaptured = scoreSummary.Captured
-	breakdown.TeleopDefensesBreached = scoreSummary.Breached
+	breakdown.TeleopTowerCaptured = strongholdSummary.Captured
+	breakdown.TeleopDefensesBreached = strongholdSummary.Breached
 	breakdown.Position1Crossings = score.AutoDefensesCrossed[0] + score.DefensesCrossed[0]
 	breakdown.Position2Crossings = score.AutoDefensesCrossed[1] + score.DefensesCrossed[1]
 	breakdown.Position3Crossings = score.AutoDefensesCrossed[2] + score.DefensesCrossed[2]
@@ -461,10 +496,10 @@ func createTbaScoringBreakdown(match *Match, matchResult *MatchResult, alliance
 	breakdown.TeleopChallengePoints = 5 * score.Challenges
 	breakdown.TeleopScalePoints = 15 * score.Scales
 	if match.Type == "elimination" {
-		if scoreSummary.Breached {
+		if strongholdSummary.Breached {
 			breakdown.BreachPoints = 20
 		}
-		if scoreSummary.Captured {
+		if strongholdSummary.Captured {
 			breakdown.CapturePoints = 25
 		}
 	}
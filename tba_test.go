@@ -96,18 +96,18 @@ func TestPublishRankings(t *testing.T) {
 	assert.Nil(t, err)
 	defer db.Close()
 	eventSettings, _ = db.GetEventSettings()
-	db.CreateRanking(&Ranking{1114, 2, 20, 625, 90, 554, 10, 0.254, 3, 2, 1, 0, 10})
-	db.CreateRanking(&Ranking{254, 1, 20, 625, 90, 554, 10, 0.254, 1, 2, 3, 0, 10})
+	db.CreateRanking(&Ranking{1114, 2, 20, []int{625, 90, 554, 10}, 0.254, 3, 2, 1, 0, 10})
+	db.CreateRanking(&Ranking{254, 1, 20, []int{625, 90, 554, 10}, 0.254, 1, 2, 3, 0, 10})
 
 	// Mock the TBA server.
 	tbaServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var reader bytes.Buffer
 		reader.ReadFrom(r.Body)
 		assert.Equal(t, "{\"breakdowns\":[\"RP\",\"Auto\",\"Scale/Challenge\",\"Goal\",\"Defense\",\"W-L-T"+
-			"\"],\"rankings\":[{\"team_key\":\"frc254\",\"rank\":1,\"RP\":20,\"Auto\":625,\"Scale/Challenge"+
-			"\":90,\"Goal\":554,\"Defense\":10,\"W-L-T\":\"1-2-3\",\"dqs\":0,\"played\":10},{\"team_key\":"+
-			"\"frc1114\",\"rank\":2,\"RP\":20,\"Auto\":625,\"Scale/Challenge\":90,\"Goal\":554,\"Defense\":"+
-			"10,\"W-L-T\":\"3-2-1\",\"dqs\":0,\"played\":10}]}", reader.String())
+			"\"],\"rankings\":[{\"Auto\":625,\"Defense\":10,\"Goal\":554,\"RP\":20,\"Scale/Challenge\":90,"+
+			"\"W-L-T\":\"1-2-3\",\"dqs\":0,\"played\":10,\"rank\":1,\"team_key\":\"frc254\"},{\"Auto\":625,"+
+			"\"Defense\":10,\"Goal\":554,\"RP\":20,\"Scale/Challenge\":90,\"W-L-T\":\"3-2-1\",\"dqs\":0,"+
+			"\"played\":10,\"rank\":2,\"team_key\":\"frc1114\"}]}", reader.String())
 	}))
 	defer tbaServer.Close()
 	tbaBaseUrl = tbaServer.URL
@@ -6,20 +6,29 @@
 package main
 
 import (
+	"encoding/csv"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 )
 
-const logsDir = "static/logs"
+const (
+	logsDir        = "static/logs"
+	logsArchiveDir = "static/logs/archive"
+)
 
 type TeamMatchLog struct {
-	logger  *log.Logger
-	logFile *os.File
+	logger         *log.Logger
+	logFile        *os.File
+	messagesWriter *csv.Writer
+	messagesFile   *os.File
 }
 
-// Creates a file to log to for the given match and team.
+// Creates a file to log to for the given match and team, along with one to capture the messages from the robot.
 func NewTeamMatchLog(teamId int, match *Match) (*TeamMatchLog, error) {
 	err := os.MkdirAll(logsDir, 0755)
 	if err != nil {
@@ -32,14 +41,26 @@ func NewTeamMatchLog(teamId int, match *Match) (*TeamMatchLog, error) {
 	if err != nil {
 		return nil, err
 	}
+	messagesFile, err := os.Create(matchLogMessagesFilename(filename))
+	if err != nil {
+		logFile.Close()
+		return nil, err
+	}
 
-	log := TeamMatchLog{log.New(logFile, "", 0), logFile}
+	log := TeamMatchLog{log.New(logFile, "", 0), logFile, csv.NewWriter(messagesFile), messagesFile}
 	log.logger.Println("matchTimeSec,packetType,teamId,allianceStation,robotLinked,auto,enabled," +
 		"emergencyStop,batteryVoltage,missedPacketCount,dsRobotTripTimeMs")
+	log.messagesWriter.Write([]string{"matchTimeSec", "time", "level", "message"})
+	log.messagesWriter.Flush()
 
 	return &log, nil
 }
 
+// Returns the name of the file holding the robot messages that accompanies the given team match log.
+func matchLogMessagesFilename(filename string) string {
+	return strings.TrimSuffix(filename, ".csv") + "_messages.csv"
+}
+
 // Adds a line to the log when a packet is received.
 func (log *TeamMatchLog) LogDsPacket(matchTimeSec float64, packetType int, dsConn *DriverStationConnection) {
 	log.logger.Printf("%f,%d,%d,%s,%v,%v,%v,%v,%f,%d,%d", matchTimeSec, packetType, dsConn.TeamId,
@@ -47,6 +68,41 @@ func (log *TeamMatchLog) LogDsPacket(matchTimeSec float64, packetType int, dsCon
 		dsConn.BatteryVoltage, dsConn.MissedPacketCount, dsConn.DsRobotTripTimeMs)
 }
 
+// Adds a line to the messages log when the DS forwards a console or error message.
+func (log *TeamMatchLog) LogDsMessage(message *DsMessage) {
+	log.messagesWriter.Write([]string{fmt.Sprintf("%f", message.MatchTimeSec),
+		message.Time.Format("15:04:05.000"), message.Level, message.Text})
+	log.messagesWriter.Flush()
+}
+
 func (log *TeamMatchLog) Close() {
 	log.logFile.Close()
+	log.messagesFile.Close()
+}
+
+// Moves all existing team match logs into a timestamped subdirectory of the archive, so that they aren't mistaken for
+// the logs of new matches that reuse the same names.
+func ArchiveMatchLogs(reason string) error {
+	fileInfos, err := ioutil.ReadDir(logsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	archiveDir := filepath.Join(logsArchiveDir, fmt.Sprintf("%s_%s", time.Now().Format("20060102150405"), reason))
+	for _, fileInfo := range fileInfos {
+		if fileInfo.IsDir() {
+			continue
+		}
+		err = os.MkdirAll(archiveDir, 0755)
+		if err != nil {
+			return err
+		}
+		err = os.Rename(filepath.Join(logsDir, fileInfo.Name()), filepath.Join(archiveDir, fileInfo.Name()))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
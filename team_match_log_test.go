@@ -0,0 +1,62 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTeamMatchLogMessages(t *testing.T) {
+	match := Match{Type: "qualification", DisplayName: "34"}
+	log, err := NewTeamMatchLog(9254, &match)
+	assert.Nil(t, err)
+	logFilename := log.logFile.Name()
+	defer os.Remove(logFilename)
+	messagesFilename := log.messagesFile.Name()
+	defer os.Remove(messagesFilename)
+	assert.Equal(t, matchLogMessagesFilename(logFilename), messagesFilename)
+
+	messageTime := time.Date(2016, 10, 19, 10, 30, 5, 0, time.Local)
+	log.LogDsMessage(&DsMessage{messageTime, 12.5, "error", "Unhandled exception: \"NullPointerException\", at 1,2"})
+	log.Close()
+
+	contents, err := ioutil.ReadFile(messagesFilename)
+	assert.Nil(t, err)
+	assert.Equal(t, "matchTimeSec,time,level,message\n12.500000,10:30:05.000,error,"+
+		"\"Unhandled exception: \"\"NullPointerException\"\", at 1,2\"\n", string(contents))
+
+	// The messages file shouldn't be mistaken for a team match log.
+	_, ok := parseMatchLogFilename(filepath.Base(messagesFilename))
+	assert.False(t, ok)
+}
+
+func TestArchiveMatchLogs(t *testing.T) {
+	writeTestMatchLog(t)
+	defer removeTestMatchLog()
+
+	assert.Nil(t, ArchiveMatchLogs("test"))
+	_, err := os.Stat(filepath.Join(logsDir, testMatchLogFilename))
+	assert.True(t, os.IsNotExist(err))
+	files, err := GetMatchLogFiles(&Match{Type: "qualification", DisplayName: "12"})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(files))
+
+	archivedFilenames, _ := filepath.Glob(filepath.Join(logsArchiveDir, "*_test", testMatchLogFilename))
+	if assert.Equal(t, 1, len(archivedFilenames)) {
+		archiveDir := filepath.Dir(archivedFilenames[0])
+		defer os.RemoveAll(archiveDir)
+		_, err = os.Stat(filepath.Join(archiveDir, matchLogMessagesFilename(testMatchLogFilename)))
+		assert.Nil(t, err)
+	}
+
+	// Archiving again should leave the previous archive in place.
+	assert.Nil(t, ArchiveMatchLogs("test"))
+	archivedFilenames, _ = filepath.Glob(filepath.Join(logsArchiveDir, "*_test", testMatchLogFilename))
+	assert.Equal(t, 1, len(archivedFilenames))
+}
@@ -0,0 +1,138 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Arena logic for tracking alliance and field timeouts between matches.
+
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+const allianceTimeoutDurationSec = 360
+
+type TimeoutStatus struct {
+	Type         string
+	RemainingSec int
+}
+
+// Starts the given alliance's timeout, if it has not already used its one timeout for the eliminations.
+func (arena *Arena) StartAllianceTimeout(alliance string) error {
+	if alliance != "red" && alliance != "blue" {
+		return fmt.Errorf("Invalid alliance '%s'.", alliance)
+	}
+	if arena.currentMatch.Type != "elimination" {
+		return fmt.Errorf("Alliance timeouts may only be called during the eliminations.")
+	}
+	if err := arena.checkCanStartTimeout(); err != nil {
+		return err
+	}
+	allianceId, err := arena.getCurrentAllianceId(alliance)
+	if err != nil {
+		return err
+	}
+	if allianceId == 0 {
+		return fmt.Errorf("Cannot determine the %s alliance for the current match.", alliance)
+	}
+	usedTimeout, err := db.GetAllianceTimeoutByAllianceId(allianceId)
+	if err != nil {
+		return err
+	}
+	if usedTimeout != nil {
+		return fmt.Errorf("Alliance %d has already used its timeout.", allianceId)
+	}
+
+	// Record the timeout in the database so that it stays used if the server is restarted.
+	err = db.CreateAllianceTimeout(&AllianceTimeout{AllianceId: allianceId, MatchId: arena.currentMatch.Id,
+		Time: time.Now()})
+	if err != nil {
+		return err
+	}
+	arena.startTimeout(alliance, allianceTimeoutDurationSec)
+	return nil
+}
+
+// Starts a field timeout of the given length.
+func (arena *Arena) StartFieldTimeout(durationSec int) error {
+	if durationSec < 1 {
+		return fmt.Errorf("Field timeout duration must be at least 1 second.")
+	}
+	if err := arena.checkCanStartTimeout(); err != nil {
+		return err
+	}
+	arena.startTimeout("field", durationSec)
+	return nil
+}
+
+// Ends any timeout in progress before it has expired.
+func (arena *Arena) ClearTimeout() {
+	if arena.timeoutType != "" {
+		arena.timeoutType = ""
+		arena.timeoutNotifier.Notify(nil)
+	}
+}
+
+// Returns the type of timeout in progress and how much of it is left.
+func (arena *Arena) TimeoutStatus() TimeoutStatus {
+	if arena.timeoutType == "" {
+		return TimeoutStatus{}
+	}
+	remainingSec := int(arena.timeoutEndTime.Sub(time.Now()).Seconds() + 0.999)
+	if remainingSec < 0 {
+		remainingSec = 0
+	}
+	return TimeoutStatus{arena.timeoutType, remainingSec}
+}
+
+// Expires the timeout once its time is up and sends a notification whenever the countdown ticks over.
+func (arena *Arena) updateTimeout() {
+	if arena.timeoutType == "" {
+		return
+	}
+	status := arena.TimeoutStatus()
+	if status.RemainingSec == 0 {
+		arena.timeoutType = ""
+		arena.timeoutNotifier.Notify(nil)
+	} else if status.RemainingSec != arena.lastTimeoutRemainingSec {
+		arena.timeoutNotifier.Notify(nil)
+	}
+	arena.lastTimeoutRemainingSec = status.RemainingSec
+}
+
+func (arena *Arena) checkCanStartTimeout() error {
+	if arena.MatchState != PRE_MATCH {
+		return fmt.Errorf("Cannot start a timeout while there is a match still in progress or with results pending.")
+	}
+	if arena.timeoutType != "" {
+		return fmt.Errorf("Cannot start a timeout while another one is in progress.")
+	}
+	return nil
+}
+
+func (arena *Arena) startTimeout(timeoutType string, durationSec int) {
+	arena.timeoutType = timeoutType
+	arena.timeoutEndTime = time.Now().Add(time.Duration(durationSec) * time.Second)
+	arena.lastTimeoutRemainingSec = durationSec
+	arena.timeoutNotifier.Notify(nil)
+}
+
+// Returns the number of the alliance playing in the given color in the current match, or 0 if it can't be found.
+func (arena *Arena) getCurrentAllianceId(alliance string) (int, error) {
+	teamId := arena.currentMatch.Red1
+	if alliance == "blue" {
+		teamId = arena.currentMatch.Blue1
+	}
+	alliances, err := db.GetAllAlliances()
+	if err != nil {
+		return 0, err
+	}
+	for _, allianceTeams := range alliances {
+		for _, allianceTeam := range allianceTeams {
+			if allianceTeam.TeamId == teamId {
+				return allianceTeam.AllianceId, nil
+			}
+		}
+	}
+	return 0, nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2016 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAllianceTimeouts(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+	mainArena.Setup()
+	createTestAlliances(db, 2)
+
+	// Alliance timeouts aren't allowed outside of the eliminations.
+	err = mainArena.StartAllianceTimeout("red")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "only be called during the eliminations")
+	}
+
+	match := Match{Type: "elimination", DisplayName: "F-1", Red1: 1, Red2: 1, Red3: 1, Blue1: 2, Blue2: 2, Blue3: 2}
+	db.CreateMatch(&match)
+	mainArena.LoadMatch(&match)
+	mainArena.AllianceStations["R1"].Bypass = true
+	mainArena.AllianceStations["R2"].Bypass = true
+	mainArena.AllianceStations["R3"].Bypass = true
+	mainArena.AllianceStations["B1"].Bypass = true
+	mainArena.AllianceStations["B2"].Bypass = true
+	mainArena.AllianceStations["B3"].Bypass = true
+	assert.Nil(t, mainArena.CheckCanStartMatch())
+
+	err = mainArena.StartAllianceTimeout("green")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "Invalid alliance")
+	}
+	assert.Nil(t, mainArena.StartAllianceTimeout("blue"))
+	assert.Equal(t, TimeoutStatus{"blue", allianceTimeoutDurationSec}, mainArena.TimeoutStatus())
+	err = mainArena.CheckCanStartMatch()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "timeout is in progress")
+	}
+	err = mainArena.StartAllianceTimeout("red")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "another one is in progress")
+	}
+
+	// The timeout should expire on its own once its time is up.
+	mainArena.timeoutEndTime = time.Now().Add(-time.Millisecond)
+	mainArena.Update()
+	assert.Equal(t, TimeoutStatus{}, mainArena.TimeoutStatus())
+	assert.Nil(t, mainArena.CheckCanStartMatch())
+
+	// Each alliance only gets one timeout.
+	err = mainArena.StartAllianceTimeout("blue")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "Alliance 2 has already used its timeout")
+	}
+	assert.Nil(t, mainArena.StartAllianceTimeout("red"))
+	mainArena.ClearTimeout()
+	assert.Equal(t, TimeoutStatus{}, mainArena.TimeoutStatus())
+	err = mainArena.StartAllianceTimeout("red")
+	assert.NotNil(t, err)
+
+	// Used timeouts should be remembered across a restart.
+	allianceTimeout, err := db.GetAllianceTimeoutByAllianceId(2)
+	assert.Nil(t, err)
+	if assert.NotNil(t, allianceTimeout) {
+		assert.Equal(t, match.Id, allianceTimeout.MatchId)
+	}
+	mainArena.Setup()
+	mainArena.LoadMatch(&match)
+	err = mainArena.StartAllianceTimeout("blue")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "Alliance 2 has already used its timeout")
+	}
+}
+
+func TestFieldTimeout(t *testing.T) {
+	clearDb()
+	defer clearDb()
+	var err error
+	db, err = OpenDatabase(testDbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+	eventSettings, _ = db.GetEventSettings()
+	mainArena.Setup()
+
+	err = mainArena.StartFieldTimeout(0)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "at least 1 second")
+	}
+	assert.Nil(t, mainArena.StartFieldTimeout(300))
+	assert.Equal(t, TimeoutStatus{"field", 300}, mainArena.TimeoutStatus())
+	mainArena.timeoutEndTime = time.Now().Add(90*time.Second + 500*time.Millisecond)
+	assert.Equal(t, TimeoutStatus{"field", 91}, mainArena.TimeoutStatus())
+	mainArena.ClearTimeout()
+	assert.Equal(t, TimeoutStatus{}, mainArena.TimeoutStatus())
+
+	// Timeouts can't be started while a match is in progress.
+	mainArena.MatchState = AUTO_PERIOD
+	err = mainArena.StartFieldTimeout(300)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "match still in progress")
+	}
+	mainArena.MatchState = PRE_MATCH
+}
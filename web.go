@@ -11,6 +11,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
 	"log"
+	"net"
 	"net/http"
 	"sync"
 	"text/template"
@@ -144,6 +145,22 @@ func UserIsReader(w http.ResponseWriter, r *http.Request) bool {
 	return true
 }
 
+// Returns the name and address of the user making the given request, for attributing changes in the audit trail.
+func getRequestUser(r *http.Request) string {
+	user := adminAuth.Authorize(r)
+	if user == "" {
+		user = "anonymous"
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if host == "" {
+		return user
+	}
+	return fmt.Sprintf("%s@%s", user, host)
+}
+
 func checkAdminPassword(user, password string) bool {
 	return user == adminUser && password == eventSettings.AdminPassword
 }
@@ -160,6 +177,7 @@ func checkReaderPassword(user, password string) bool {
 // Sets up the mapping between URLs and handlers.
 func newHandler() http.Handler {
 	router := mux.NewRouter()
+	router.HandleFunc("/setup/wizard", SetupWizardGetHandler).Methods("GET")
 	router.HandleFunc("/setup/settings", SettingsGetHandler).Methods("GET")
 	router.HandleFunc("/setup/settings", SettingsPostHandler).Methods("POST")
 	router.HandleFunc("/setup/db/save", SaveDbHandler).Methods("GET")
@@ -175,8 +193,11 @@ func newHandler() http.Handler {
 	router.HandleFunc("/setup/teams/generate_wpa_keys", TeamsGenerateWpaKeysHandler).Methods("GET")
 	router.HandleFunc("/setup/schedule", ScheduleGetHandler).Methods("GET")
 	router.HandleFunc("/setup/schedule/generate", ScheduleGeneratePostHandler).Methods("POST")
+	router.HandleFunc("/setup/schedule/quality", ScheduleGeneratedQualityHandler).Methods("GET")
+	router.HandleFunc("/setup/schedule/candidates/{index}/select", ScheduleCandidateSelectPostHandler).Methods("POST")
 	router.HandleFunc("/setup/schedule/republish", ScheduleRepublishPostHandler).Methods("POST")
 	router.HandleFunc("/setup/schedule/save", ScheduleSavePostHandler).Methods("POST")
+	router.HandleFunc("/setup/schedule/blocks/{blockId}/shift", ScheduleBlockShiftPostHandler).Methods("POST")
 	router.HandleFunc("/setup/alliance_selection", AllianceSelectionGetHandler).Methods("GET")
 	router.HandleFunc("/setup/alliance_selection", AllianceSelectionPostHandler).Methods("POST")
 	router.HandleFunc("/setup/alliance_selection/start", AllianceSelectionStartHandler).Methods("POST")
@@ -186,11 +207,15 @@ func newHandler() http.Handler {
 	router.HandleFunc("/setup/field", FieldPostHandler).Methods("POST")
 	router.HandleFunc("/setup/field/reload_displays", FieldReloadDisplaysHandler).Methods("GET")
 	router.HandleFunc("/setup/field/lights", FieldLightsPostHandler).Methods("POST")
+	router.HandleFunc("/setup/field/ds_simulator", FieldDsSimulatorPostHandler).Methods("POST")
 	router.HandleFunc("/setup/lower_thirds", LowerThirdsGetHandler).Methods("GET")
 	router.HandleFunc("/setup/lower_thirds/websocket", LowerThirdsWebsocketHandler).Methods("GET")
 	router.HandleFunc("/setup/sponsor_slides", SponsorSlidesGetHandler).Methods("GET")
 	router.HandleFunc("/setup/sponsor_slides", SponsorSlidesPostHandler).Methods("POST")
 	router.HandleFunc("/api/sponsor_slides", SponsorSlidesApiHandler).Methods("GET")
+	router.HandleFunc("/setup/awards", AwardsGetHandler).Methods("GET")
+	router.HandleFunc("/setup/awards", AwardsPostHandler).Methods("POST")
+	router.HandleFunc("/setup/awards/publish", AwardsPublishHandler).Methods("POST")
 	router.HandleFunc("/setup/defense_selection", DefenseSelectionGetHandler).Methods("GET")
 	router.HandleFunc("/setup/defense_selection", DefenseSelectionPostHandler).Methods("POST")
 	router.HandleFunc("/match_play", MatchPlayHandler).Methods("GET")
@@ -200,11 +225,19 @@ func newHandler() http.Handler {
 	router.HandleFunc("/match_review", MatchReviewHandler).Methods("GET")
 	router.HandleFunc("/match_review/{matchId}/edit", MatchReviewEditGetHandler).Methods("GET")
 	router.HandleFunc("/match_review/{matchId}/edit", MatchReviewEditPostHandler).Methods("POST")
+	router.HandleFunc("/match_review/{matchId}/unscore", MatchReviewUnscorePostHandler).Methods("POST")
+	router.HandleFunc("/match_review/{matchId}/history", MatchReviewHistoryHandler).Methods("GET")
+	router.HandleFunc("/logs", MatchLogsGetHandler).Methods("GET")
+	router.HandleFunc("/logs/{filename}", MatchLogGetHandler).Methods("GET")
 	router.HandleFunc("/reports/csv/rankings", RankingsCsvReportHandler).Methods("GET")
 	router.HandleFunc("/reports/pdf/rankings", RankingsPdfReportHandler).Methods("GET")
+	router.HandleFunc("/reports/pdf/bracket", BracketPdfReportHandler).Methods("GET")
+	router.HandleFunc("/reports/connection_history", ConnectionHistoryReportHandler).Methods("GET")
+	router.HandleFunc("/reports/connection_history/{teamId}", TeamConnectionHistoryReportHandler).Methods("GET")
 	router.HandleFunc("/reports/csv/schedule/{type}", ScheduleCsvReportHandler).Methods("GET")
 	router.HandleFunc("/reports/pdf/schedule/{type}", SchedulePdfReportHandler).Methods("GET")
 	router.HandleFunc("/reports/pdf/defenses/{type}", DefensesPdfReportHandler).Methods("GET")
+	router.HandleFunc("/reports/schedule_quality/{type}", ScheduleQualityReportHandler).Methods("GET")
 	router.HandleFunc("/reports/csv/teams", TeamsCsvReportHandler).Methods("GET")
 	router.HandleFunc("/reports/pdf/teams", TeamsPdfReportHandler).Methods("GET")
 	router.HandleFunc("/reports/csv/wpa_keys", WpaKeysCsvReportHandler).Methods("GET")
@@ -223,7 +256,11 @@ func newHandler() http.Handler {
 	router.HandleFunc("/displays/fta", FtaDisplayHandler).Methods("GET")
 	router.HandleFunc("/displays/fta/websocket", FtaDisplayWebsocketHandler).Methods("GET")
 	router.HandleFunc("/api/matches/{type}", MatchesApiHandler).Methods("GET")
+	router.HandleFunc("/api/matches/{id}/results", MatchResultsApiHandler).Methods("GET")
 	router.HandleFunc("/api/rankings", RankingsApiHandler).Methods("GET")
+	router.HandleFunc("/api/bracket", BracketApiHandler).Methods("GET")
+	router.HandleFunc("/api/stations/{station}/emergency_stop", EmergencyStopApiHandler).Methods("POST")
+	router.HandleFunc("/api/stations/{station}/a_stop", AStopApiHandler).Methods("POST")
 	router.HandleFunc("/", IndexHandler).Methods("GET")
 	return router
 }